@@ -0,0 +1,55 @@
+package sequence
+
+import "time"
+
+// A Budget is an estimated cost to complete missing frames.
+type Budget struct {
+	Missing int
+	Time    time.Duration
+	Cost    float64
+}
+
+// add adds another budget to the budget.
+func (b *Budget) add(o Budget) {
+	b.Missing += o.Missing
+	b.Time += o.Time
+	b.Cost += o.Cost
+}
+
+// EstimateBudget estimates the render time and cost to complete
+// each sequence's expected range, with per frame time and cost estimates.
+// It returns the budget of each sequence in expected, and their total.
+//
+// A sequence in expected that the manager does not have
+// is treated as it misses all the frames. Excluded frames are not counted.
+// Sequences with a nil range are skipped.
+func (m *Manager) EstimateBudget(expected map[string]*Range, perFrame time.Duration, costPerFrame float64) (map[string]Budget, Budget) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	budgets := make(map[string]Budget)
+	total := Budget{}
+	for n, r := range expected {
+		if r == nil {
+			continue
+		}
+		missing := 0
+		s, ok := m.Seqs[n]
+		for f := r.Min; f <= r.Max; f += r.step() {
+			if !ok {
+				missing++
+				continue
+			}
+			if !s.frames.has(f) && !s.Excluded(f) {
+				missing++
+			}
+		}
+		b := Budget{
+			Missing: missing,
+			Time:    time.Duration(missing) * perFrame,
+			Cost:    float64(missing) * costPerFrame,
+		}
+		budgets[n] = b
+		total.add(b)
+	}
+	return budgets, total
+}
@@ -0,0 +1,36 @@
+package sequence
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEstimateBudget(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"a.1.exr", "a.2.exr", "a.5.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	bad := NewSeq()
+	bad.AddFrame(6)
+	man.Seqs["a.#.exr"].Exclude(bad)
+	expected := map[string]*Range{
+		"a.#.exr": {Min: 1, Max: 7},
+		"b.#.exr": {Min: 1, Max: 2},
+		"c.#.exr": nil,
+	}
+	got, total := man.EstimateBudget(expected, 10*time.Minute, 1.5)
+	want := map[string]Budget{
+		"a.#.exr": {Missing: 3, Time: 30 * time.Minute, Cost: 4.5},
+		"b.#.exr": {Missing: 2, Time: 20 * time.Minute, Cost: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+	wantTotal := Budget{Missing: 5, Time: 50 * time.Minute, Cost: 7.5}
+	if total != wantTotal {
+		t.Fatalf("got total: %v, want: %v", total, wantTotal)
+	}
+}
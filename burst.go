@@ -0,0 +1,90 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// A Burst is a part of a sequence, whose frames were written
+// close to each other in time, like a burst of a time-lapse camera.
+type Burst struct {
+	// Frames is the range from the first to the last frame of the burst.
+	Frames *Range
+
+	// Start and End are the earliest and latest modification times
+	// of the burst's frames.
+	Start time.Time
+	End   time.Time
+}
+
+// Bursts splits the sequence into bursts, where modification times of
+// neighbor frames are more than gap apart. It does not look at frame gaps.
+//
+// mtimes holds modification times of frames, see Manager.ModTimes.
+// Frames without a modification time are skipped.
+func (s *Seq) Bursts(mtimes map[int]time.Time, gap time.Duration) []Burst {
+	bursts := []Burst{}
+	var cur *Burst
+	var last time.Time
+	for f := range s.frames.all() {
+		t, ok := mtimes[f]
+		if !ok {
+			continue
+		}
+		if cur == nil || absDuration(t.Sub(last)) > gap {
+			bursts = append(bursts, Burst{Frames: NewRange(f), Start: t, End: t})
+			cur = &bursts[len(bursts)-1]
+		}
+		cur.Frames.Max = f
+		if t.Before(cur.Start) {
+			cur.Start = t
+		}
+		if t.After(cur.End) {
+			cur.End = t
+		}
+		last = t
+	}
+	return bursts
+}
+
+// absDuration returns the absolute value of d.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// ModTimes returns modification times of a sequence's frames in fsys,
+// which the sequence's file names should be the paths in, like after Scan.
+//
+// Frames those could not be stat are returned as joined errors,
+// with times of the others.
+func (m *Manager) ModTimes(fsys fs.FS, name string) (map[int]time.Time, error) {
+	m.mu.RLock()
+	s, ok := m.Seqs[name]
+	if !ok {
+		m.mu.RUnlock()
+		return nil, fmt.Errorf("%w: %s", ErrSeqNotFound, name)
+	}
+	frames := s.sortedFrames()
+	files := make([]string, len(frames))
+	for i, f := range frames {
+		files[i] = m.frameKey(name, f).FileName(f)
+	}
+	m.mu.RUnlock()
+
+	mtimes := make(map[int]time.Time, len(frames))
+	errs := []error{}
+	for i, f := range frames {
+		fi, err := fs.Stat(fsys, files[i])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		mtimes[f] = fi.ModTime()
+	}
+	return mtimes, errors.Join(errs...)
+}
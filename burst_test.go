@@ -0,0 +1,76 @@
+package sequence
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestBursts(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{}
+	add := func(name string, mtime time.Time) {
+		fsys[name] = &fstest.MapFile{ModTime: mtime}
+	}
+	// Frame gaps do not split bursts, but time gaps do.
+	add("cap/img.0001.jpg", t0)
+	add("cap/img.0002.jpg", t0.Add(2*time.Second))
+	add("cap/img.0005.jpg", t0.Add(4*time.Second))
+	add("cap/img.0006.jpg", t0.Add(time.Hour))
+	add("cap/img.0007.jpg", t0.Add(time.Hour+time.Second))
+	add("cap/img.0008.jpg", t0.Add(3*time.Hour))
+
+	man := NewManager(DefaultSplitter, FmtSharp)
+	if err := man.Scan(fsys, "."); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	mtimes, err := man.ModTimes(fsys, "cap/img.####.jpg")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	s, _ := man.Seq("cap/img.####.jpg")
+	got := s.Bursts(mtimes, time.Minute)
+	want := []struct {
+		frames     string
+		start, end time.Time
+	}{
+		{frames: "1-5", start: t0, end: t0.Add(4 * time.Second)},
+		{frames: "6-7", start: t0.Add(time.Hour), end: t0.Add(time.Hour + time.Second)},
+		{frames: "8", start: t0.Add(3 * time.Hour), end: t0.Add(3 * time.Hour)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got: %d bursts, want: %d", len(got), len(want))
+	}
+	for i, w := range want {
+		b := got[i]
+		if b.Frames.String() != w.frames || !b.Start.Equal(w.start) || !b.End.Equal(w.end) {
+			t.Fatalf("got: %v %v-%v, want: %v %v-%v", b.Frames, b.Start, b.End, w.frames, w.start, w.end)
+		}
+	}
+
+	delete(fsys, "cap/img.0008.jpg")
+	mtimes, err = man.ModTimes(fsys, "cap/img.####.jpg")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("got err: %v, want: %v", err, fs.ErrNotExist)
+	}
+	if len(mtimes) != 5 {
+		t.Fatalf("got: %d mtimes, want: 5", len(mtimes))
+	}
+
+	// Merged frames are stat with their own padding.
+	add("pad/img.99.jpg", t0)
+	add("pad/img.0100.jpg", t0)
+	man = New(WithPadPolicy(PadWidest))
+	if err := man.Scan(fsys, "pad"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	mtimes, err = man.ModTimes(fsys, "pad/img.####.jpg")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if len(mtimes) != 2 {
+		t.Fatalf("got: %d mtimes, want: 2", len(mtimes))
+	}
+}
@@ -0,0 +1,125 @@
+// Package seqop has the shared parts of the seqmv and seqcp commands.
+package seqop
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/kybin/sequence"
+)
+
+// Plan finds the sequence of src pattern on disk, like "data/img.####.exr",
+// and returns the file renames to dst pattern.
+// When dst is a directory, the sequence keeps it's name in there.
+// When step is not 0, the frames are renumbered to start and step.
+func Plan(src, dst string, start, step int) ([]sequence.Rename, error) {
+	sk, err := sequence.ParseName(src)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(sk.Pre + "_")
+	sk.Pre = cleanPre(dir, sk.Pre)
+	dk, err := sequence.ParseName(dst)
+	if err != nil {
+		fi, serr := os.Stat(dst)
+		if !errors.Is(err, sequence.ErrNoFrameToken) || serr != nil || !fi.IsDir() {
+			return nil, err
+		}
+		dk = sk
+		dk.Pre = cleanPre(dst, sk.Pre)
+	}
+
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	man := sequence.New()
+	for _, e := range ents {
+		if e.IsDir() {
+			continue
+		}
+		err := man.Add(filepath.Join(dir, e.Name()))
+		if err != nil && !errors.Is(err, sequence.ErrNotSeqfile) {
+			return nil, err
+		}
+	}
+	return man.RenamePlan(sk.Format(sequence.FmtSharp), dk, start, step)
+}
+
+// cleanPre returns pre part of a key in dir, with the base part of pre.
+func cleanPre(dir, pre string) string {
+	p := filepath.Join(dir, filepath.Base(pre+"_"))
+	return p[:len(p)-1]
+}
+
+// usageError prints an error and the usage, and returns the exit code of it.
+func usageError(fset *flag.FlagSet, stderr io.Writer, name, msg string) int {
+	fmt.Fprintf(stderr, "%s: %s\n", name, msg)
+	fset.Usage()
+	return 2
+}
+
+// Main runs a command with it's arguments, and returns the exit code.
+// apply applies the planned renames, like fsops.Rename or fsops.Copy.
+func Main(name string, apply func([]sequence.Rename) error, args []string, stdout, stderr io.Writer) int {
+	fset := flag.NewFlagSet(name, flag.ContinueOnError)
+	fset.SetOutput(stderr)
+	dryRun := fset.Bool("dry-run", false, "print the plan without touching files")
+	start := fset.Int("start", 0, "renumber the frames from this frame")
+	step := fset.Int("step", 1, "step of the renumbered frames, used with -start")
+	fset.Usage = func() {
+		fmt.Fprintf(stderr, "usage: %s [-dry-run] [-start N [-step N]] SRC DST\n", name)
+		fset.PrintDefaults()
+	}
+	if err := fset.Parse(args); err != nil {
+		return 2
+	}
+	if fset.NArg() != 2 {
+		fset.Usage()
+		return 2
+	}
+	set := make(map[string]bool)
+	fset.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	if set["step"] && !set["start"] {
+		return usageError(fset, stderr, name, "-step should be used with -start")
+	}
+	if set["start"] && *step < 1 {
+		return usageError(fset, stderr, name, fmt.Sprintf("-step should be 1 or bigger, got %d", *step))
+	}
+	if !set["start"] {
+		*step = 0
+	}
+	// Without a padding, the pattern could not tell the file names.
+	if k, err := sequence.ParseName(fset.Arg(0)); err == nil && k.Pad == 0 {
+		return usageError(fset, stderr, name, "SRC should have a padded frame token, like %04d or ####")
+	}
+
+	renames, err := Plan(fset.Arg(0), fset.Arg(1), *start, *step)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", name, err)
+		return 1
+	}
+	if *dryRun {
+		for _, r := range renames {
+			fmt.Fprintf(stdout, "%s -> %s\n", r.From, r.To)
+		}
+		return 0
+	}
+	if len(renames) != 0 {
+		if err := os.MkdirAll(filepath.Dir(renames[0].To), 0755); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", name, err)
+			return 1
+		}
+	}
+	if err := apply(renames); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
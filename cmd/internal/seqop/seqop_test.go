@@ -0,0 +1,103 @@
+package seqop
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kybin/sequence/fsops"
+)
+
+// files returns sorted relative paths of files under dir.
+func files(t *testing.T, dir string) []string {
+	paths := []string{}
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestPlan(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "plates")
+	if err := os.Mkdir(src, 0755); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	for _, n := range []string{"img.1001.exr", "img.1002.exr", "other.1001.exr", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(src, n), nil, 0644); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	srcPat := filepath.Join(src, "img.####.exr")
+	dstPat := filepath.Join(dir, "sh010", "plate.%04d.exr")
+
+	var out, errOut strings.Builder
+	args := []string{"-dry-run", "-start", "1", srcPat, dstPat}
+	if code := Main("seqmv", fsops.Rename, args, &out, &errOut); code != 0 {
+		t.Fatalf("got exit code: %d, %s", code, errOut.String())
+	}
+	want := filepath.Join(src, "img.1001.exr") + " -> " + filepath.Join(dir, "sh010", "plate.0001.exr") + "\n" +
+		filepath.Join(src, "img.1002.exr") + " -> " + filepath.Join(dir, "sh010", "plate.0002.exr") + "\n"
+	if got := out.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	before := []string{"plates/img.1001.exr", "plates/img.1002.exr", "plates/notes.txt", "plates/other.1001.exr"}
+	if got := files(t, dir); !reflect.DeepEqual(got, before) {
+		t.Fatalf("dry run changed files: %q", got)
+	}
+
+	if code := Main("seqcp", fsops.Copy, []string{srcPat, dir}, &out, &errOut); code != 0 {
+		t.Fatalf("got exit code: %d, %s", code, errOut.String())
+	}
+	if code := Main("seqmv", fsops.Rename, []string{"-start", "1", srcPat, dstPat}, &out, &errOut); code != 0 {
+		t.Fatalf("got exit code: %d, %s", code, errOut.String())
+	}
+	after := []string{
+		"img.1001.exr",
+		"img.1002.exr",
+		"plates/notes.txt",
+		"plates/other.1001.exr",
+		"sh010/plate.0001.exr",
+		"sh010/plate.0002.exr",
+	}
+	if got := files(t, dir); !reflect.DeepEqual(got, after) {
+		t.Fatalf("got: %q, want: %q", got, after)
+	}
+
+	errOut.Reset()
+	if code := Main("seqmv", fsops.Rename, []string{srcPat, dstPat}, &out, &errOut); code != 1 {
+		t.Fatalf("got exit code: %d, want: 1", code)
+	}
+	usageCases := [][]string{
+		{srcPat},
+		{"-step", "2", srcPat, dstPat},
+		{"-dry-run", "-start", "5", "-step", "0", srcPat, dstPat},
+		{"-start", "5", "-step", "-1", srcPat, dstPat},
+		{filepath.Join(src, "img.%d.exr"), dstPat},
+		{"-start", "1", filepath.Join(src, "img.$F.exr"), dstPat},
+	}
+	for _, args := range usageCases {
+		errOut.Reset()
+		if code := Main("seqmv", fsops.Rename, args, &out, &errOut); code != 2 {
+			t.Fatalf("%q: got exit code: %d, want: 2", args, code)
+		}
+		if !strings.Contains(errOut.String(), "usage:") {
+			t.Fatalf("%q: usage not printed: %q", args, errOut.String())
+		}
+	}
+	if got := files(t, dir); !reflect.DeepEqual(got, after) {
+		t.Fatalf("got: %q, want: %q", got, after)
+	}
+}
@@ -0,0 +1,17 @@
+// Command seqmv moves a whole sequence by pattern, optionally renumbering it.
+//
+//	seqmv [-dry-run] [-start N [-step N]] SRC DST
+//
+// Like "seqmv -start 1 plates/img.####.exr shots/sh010/plate.####.exr".
+package main
+
+import (
+	"os"
+
+	"github.com/kybin/sequence/cmd/internal/seqop"
+	"github.com/kybin/sequence/fsops"
+)
+
+func main() {
+	os.Exit(seqop.Main("seqmv", fsops.Rename, os.Args[1:], os.Stdout, os.Stderr))
+}
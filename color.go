@@ -0,0 +1,82 @@
+package sequence
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Completeness is how complete a sequence is.
+type Completeness int
+
+const (
+	// Complete sequences do not miss any frame.
+	Complete Completeness = iota
+
+	// Incomplete sequences have gaps or miss frames of the expected range.
+	Incomplete
+
+	// Stalled sequences do not have any frame,
+	// or have failed frames those are still missing.
+	Stalled
+)
+
+// ANSI colors of each completeness, which WriteColored uses.
+var completenessColors = map[Completeness]string{
+	Complete:   "\x1b[32m",
+	Incomplete: "\x1b[33m",
+	Stalled:    "\x1b[31m",
+}
+
+// Completeness returns how complete the sequence is in the expected range.
+// When expected is nil, it checks the gaps between it's first and last frames.
+// Excluded frames are not treated as missing.
+func (s *Seq) Completeness(expected *Range) Completeness {
+	var missing []int
+	if expected == nil {
+		min, max, ok := s.bounds()
+		if !ok {
+			return Stalled
+		}
+		missing = s.Missing(min, max)
+	} else {
+		have := 0
+		for f := expected.Min; f <= expected.Max; f += expected.step() {
+			if s.frames.has(f) {
+				have++
+			} else if !s.Excluded(f) {
+				missing = append(missing, f)
+			}
+		}
+		if have == 0 {
+			return Stalled
+		}
+	}
+	for _, f := range missing {
+		if st, _ := s.Status(f); st == StatusFailed {
+			return Stalled
+		}
+	}
+	if len(missing) != 0 {
+		return Incomplete
+	}
+	return Complete
+}
+
+// WriteColored writes the sequences like String, but colors each line
+// with ANSI colors by it's completeness. Complete sequences are green,
+// incomplete ones are yellow, and stalled ones are red.
+//
+// Sequences in expected are checked with their expected ranges,
+// and the others with their gaps. See Seq.Completeness.
+func (m *Manager) WriteColored(w io.Writer, expected map[string]*Range) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var b strings.Builder
+	for _, n := range m.sortedNames() {
+		s := m.Seqs[n]
+		fmt.Fprintf(&b, "%s%s %s\x1b[0m\n", completenessColors[s.Completeness(expected[n])], n, s)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,60 @@
+package sequence
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompleteness(t *testing.T) {
+	cases := []struct {
+		frames   string
+		failed   []int
+		expected *Range
+		want     Completeness
+	}{
+		{frames: "1-10", want: Complete},
+		{frames: "1-4 6-10", want: Incomplete},
+		{frames: "", want: Stalled},
+		{frames: "1-10", expected: &Range{Min: 1, Max: 20}, want: Incomplete},
+		{frames: "1-9x2", expected: &Range{Min: 1, Max: 9, Step: 2}, want: Complete},
+		{frames: "1-10", expected: &Range{Min: 11, Max: 20}, want: Stalled},
+		{frames: "1-4 6-10", failed: []int{5}, want: Stalled},
+		{frames: "1-10", failed: []int{5}, want: Complete},
+	}
+	for _, c := range cases {
+		s, err := ParseSeq(c.frames)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		for _, f := range c.failed {
+			s.SetStatus(f, StatusFailed)
+		}
+		if got := s.Completeness(c.expected); got != c.want {
+			t.Fatalf("%q, %v: got: %d, want: %d", c.frames, c.expected, got, c.want)
+		}
+	}
+}
+
+func TestWriteColored(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	files := []string{
+		"/a/img.0001.exr",
+		"/a/img.0002.exr",
+		"/b/img.0001.exr",
+		"/b/img.0003.exr",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	var b strings.Builder
+	expected := map[string]*Range{"/a/img.####.exr": {Min: 1, Max: 2}}
+	if err := man.WriteColored(&b, expected); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := "\x1b[32m/a/img.####.exr 1-2\x1b[0m\n\x1b[33m/b/img.####.exr 1 3\x1b[0m\n"
+	if got := b.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package sequence_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing/fstest"
+
+	"github.com/kybin/sequence"
+)
+
+// fixture is a small render directory used by the examples.
+var fixture = fstest.MapFS{
+	"shots/sh010/beauty.1001.exr": {},
+	"shots/sh010/beauty.1002.exr": {},
+	"shots/sh010/beauty.1003.exr": {},
+	"shots/sh010/beauty.1005.exr": {},
+	"shots/sh010/depth.1001.exr":  {},
+	"shots/sh010/depth.1002.exr":  {},
+	"shots/README":                {},
+	"shots/sh020/comp.0001.dpx":   {},
+	"shots/sh020/comp.0002.dpx":   {},
+}
+
+func ExampleManager_Scan() {
+	m := sequence.New()
+	if err := m.Scan(fixture, "shots"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(m)
+	// Output:
+	// shots/sh010/beauty.####.exr 1001-1003 1005
+	// shots/sh010/depth.####.exr 1001-1002
+	// shots/sh020/comp.####.dpx 1-2
+}
+
+func ExampleExpand() {
+	s, _ := sequence.ParseSeq("1-3 5")
+	files, _ := sequence.Expand("beauty.%04d.exr", s)
+	for _, f := range files {
+		fmt.Println(f)
+	}
+	// Output:
+	// beauty.0001.exr
+	// beauty.0002.exr
+	// beauty.0003.exr
+	// beauty.0005.exr
+}
+
+func ExampleManager_Files() {
+	m := sequence.New()
+	m.Scan(fixture, "shots/sh010")
+	files, _ := m.Files("shots/sh010/depth.####.exr")
+	fmt.Println(strings.Join(files, "\n"))
+	// Output:
+	// shots/sh010/depth.1001.exr
+	// shots/sh010/depth.1002.exr
+}
+
+func ExampleSeq_Missing() {
+	m := sequence.New()
+	m.Scan(fixture, "shots")
+	s, _ := m.Seq("shots/sh010/beauty.####.exr")
+	fmt.Println(s.Missing(1001, 1006))
+	fmt.Println(s.Ranges())
+	// Output:
+	// [1004 1006]
+	// [1001-1003 1005]
+}
+
+func ExampleManager_RenumberPlan() {
+	m := sequence.New()
+	m.Scan(fixture, "shots")
+	renames, _ := m.RenumberPlan("shots/sh010/depth.####.exr", 1, 1)
+	for _, r := range renames {
+		fmt.Println(r.From, "->", r.To)
+	}
+	// Output:
+	// shots/sh010/depth.1001.exr -> shots/sh010/depth.0001.exr
+	// shots/sh010/depth.1002.exr -> shots/sh010/depth.0002.exr
+}
+
+func ExampleManager_WriteListing() {
+	m := sequence.New()
+	m.Scan(fixture, "shots")
+	m.WriteListing(os.Stdout)
+	// Output:
+	// "shots/sh010/beauty." 4 ".exr" 1001-1003 1005
+	// "shots/sh010/depth." 4 ".exr" 1001-1002
+	// "shots/sh020/comp." 4 ".dpx" 1-2
+}
@@ -0,0 +1,86 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNoFrameToken = errors.New("no frame token")
+
+// nameFormatters are pre-defined formatters ParseName parses names with.
+var nameFormatters = []Formatter{FmtUDIM, FmtAt, FmtSharp, FmtDollarF, FmtDollarFPlain, FmtFFmpeg}
+
+// ParseName returns the key of a sequence name made by a pre-defined
+// formatter, like "img.####.exr", "img.%04d.exr", "img.$F4.exr",
+// "img.@@@@.exr" or "tex.<UDIM>.png". It parses the name with
+// the formatters' Parse, and uses the right most frame token of them.
+// Percent tokens are parsed like FmtFFmpeg, so "%%" is a '%' sign.
+//
+// It returns ErrNoFrameToken when the name does not have a frame token.
+func ParseName(name string) (Key, error) {
+	var key Key
+	found := false
+	for _, f := range nameFormatters {
+		k, err := f.Parse(name)
+		if err != nil {
+			continue
+		}
+		if !found || len(k.Pre) > len(key.Pre) {
+			key, found = k, true
+		}
+	}
+	if !found {
+		return Key{}, fmt.Errorf("%w: %s", ErrNoFrameToken, name)
+	}
+	return key, nil
+}
+
+// Expand returns file names of the frames in a sequence,
+// like "img.0001.exr" and "img.0002.exr" from "img.####.exr", in frame order.
+//
+// The pattern could have a frame token of any pre-defined formatter,
+// see ParseName. It returns ErrNoFrameToken when it does not have one.
+func Expand(pattern string, seq *Seq) ([]string, error) {
+	k, err := ParseName(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return k.Files(seq), nil
+}
+
+// Files returns file names of the frames in a sequence of the key,
+// in frame order. All the frames get the key's padding,
+// use Manager.Files for a sequence merged from different paddings.
+func (k Key) Files(seq *Seq) []string {
+	frames := seq.sortedFrames()
+	files := make([]string, 0, len(frames))
+	for _, f := range frames {
+		files = append(files, k.FileName(f))
+	}
+	return files
+}
+
+// Files returns file names of a sequence in frame order,
+// which could be fed back to file operations.
+// A stereo sequence returns all views of each frame, and a sequence
+// merged by the pad policy returns each frame with it's own padding.
+func (m *Manager) Files(name string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.Seqs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSeqNotFound, name)
+	}
+	files := []string{}
+	for f := range s.frames.all() {
+		k := m.frameKey(name, f)
+		if s.views == nil {
+			files = append(files, k.FileName(f))
+			continue
+		}
+		for _, v := range s.Views(f) {
+			files = append(files, k.ViewFileName(v, f))
+		}
+	}
+	return files, nil
+}
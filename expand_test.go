@@ -0,0 +1,133 @@
+package sequence
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	s, err := ParseSeq("1-3 10")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	cases := []struct {
+		pattern string
+		want    []string
+		wantErr error
+	}{
+		{
+			pattern: "/a/img.####.exr",
+			want:    []string{"/a/img.0001.exr", "/a/img.0002.exr", "/a/img.0003.exr", "/a/img.0010.exr"},
+		},
+		{
+			pattern: "/a/img.%03d.exr",
+			want:    []string{"/a/img.001.exr", "/a/img.002.exr", "/a/img.003.exr", "/a/img.010.exr"},
+		},
+		{
+			pattern: "/a/img.$F2.exr",
+			want:    []string{"/a/img.01.exr", "/a/img.02.exr", "/a/img.03.exr", "/a/img.10.exr"},
+		},
+		{
+			pattern: "/a/img.%d.exr",
+			want:    []string{"/a/img.1.exr", "/a/img.2.exr", "/a/img.3.exr", "/a/img.10.exr"},
+		},
+		{
+			pattern: "/a/img.@@@.exr",
+			want:    []string{"/a/img.001.exr", "/a/img.002.exr", "/a/img.003.exr", "/a/img.010.exr"},
+		},
+		{
+			pattern: "/a/100%%/img.%02d.exr",
+			want:    []string{"/a/100%/img.01.exr", "/a/100%/img.02.exr", "/a/100%/img.03.exr", "/a/100%/img.10.exr"},
+		},
+		{
+			pattern: "/a/user@host/img.####.exr",
+			want:    []string{"/a/user@host/img.0001.exr", "/a/user@host/img.0002.exr", "/a/user@host/img.0003.exr", "/a/user@host/img.0010.exr"},
+		},
+		{
+			pattern: "/a/img.exr",
+			wantErr: ErrNoFrameToken,
+		},
+	}
+	for _, c := range cases {
+		got, err := Expand(c.pattern, s)
+		if !errors.Is(err, c.wantErr) {
+			t.Fatalf("%q: got err: %v, want: %v", c.pattern, err, c.wantErr)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("got: %q, want: %q", got, c.want)
+		}
+	}
+}
+
+func TestParseName(t *testing.T) {
+	cases := []struct {
+		name string
+		want Key
+	}{
+		{name: "img.####.exr", want: Key{Pre: "img.", Pad: 4, Post: ".exr"}},
+		{name: "img.$F.exr", want: Key{Pre: "img.", Pad: 0, Post: ".exr"}},
+		{name: "tex.<UDIM>.png", want: Key{Pre: "tex.", Pad: 4, Post: ".png"}},
+		{name: "v#1/img.%%.%04d.exr", want: Key{Pre: "v#1/img.%.", Pad: 4, Post: ".exr"}},
+	}
+	for _, c := range cases {
+		got, err := ParseName(c.name)
+		if err != nil {
+			t.Fatalf("%q: got error: %v", c.name, err)
+		}
+		if got != c.want {
+			t.Fatalf("%q: got: %v, want: %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestManagerFiles(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	man.SetViews(DefaultViews)
+	files := []string{
+		"/a/img.0002.exr",
+		"/a/img.0001.exr",
+		"/a/cam.right.0001.exr",
+		"/a/cam.left.0001.exr",
+		"/a/cam.left.0002.exr",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	cases := []struct {
+		name string
+		want []string
+	}{
+		{name: "/a/img.####.exr", want: []string{"/a/img.0001.exr", "/a/img.0002.exr"}},
+		{name: "/a/cam.%V.####.exr", want: []string{"/a/cam.left.0001.exr", "/a/cam.right.0001.exr", "/a/cam.left.0002.exr"}},
+	}
+	for _, c := range cases {
+		got, err := man.Files(c.name)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("got: %q, want: %q", got, c.want)
+		}
+	}
+	if _, err := man.Files("/a/none.####.exr"); !errors.Is(err, ErrSeqNotFound) {
+		t.Fatalf("got err: %v, want: %v", err, ErrSeqNotFound)
+	}
+
+	// Merged frames keep their own padding.
+	man = New(WithPadPolicy(PadWidest))
+	for _, f := range []string{"/b/img.99.exr", "/b/img.0100.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	got, err := man.Files("/b/img.####.exr")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if want := []string{"/b/img.99.exr", "/b/img.0100.exr"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
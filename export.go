@@ -0,0 +1,84 @@
+package sequence
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// NukeFrameRanges returns the frames in Nuke's frame range syntax,
+// like "1-4 98-100" or "-5--1 1-9x2". It always expresses the frames exactly,
+// regardless of the gap tolerance.
+//
+// Unlike Range.String, negative frames do not use "..",
+// which Nuke does not understand.
+func (s *Seq) NukeFrameRanges() string {
+	var b strings.Builder
+	for i, r := range s.frames.steppedRanges() {
+		if i != 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(strconv.Itoa(r.Min))
+		if r.Max != r.Min {
+			b.WriteString("-" + strconv.Itoa(r.Max))
+		}
+		if r.step() != 1 {
+			b.WriteString("x" + strconv.Itoa(r.step()))
+		}
+	}
+	return b.String()
+}
+
+// WriteRV writes an RV session (.rv) that has a source for each sequence.
+//
+// RV plays from the first to the last frame of a sequence,
+// and shows missing frames as missing.
+func (m *Manager) WriteRV(w io.Writer) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var b strings.Builder
+	b.WriteString("GTOa (4)\n\n")
+	b.WriteString("rv : RVSession (4)\n{\n    session\n    {\n        string viewNode = \"defaultSequence\"\n    }\n}\n")
+	i := 0
+	for _, n := range m.sortedNames() {
+		min, max, ok := m.Seqs[n].bounds()
+		if !ok {
+			continue
+		}
+		k := m.keys[n]
+		movie := fmt.Sprintf("%s%d-%d%s%s", k.Pre, min, max, strings.Repeat("@", k.Pad), k.Post)
+		g := fmt.Sprintf("sourceGroup%06d", i)
+		fmt.Fprintf(&b, "\n%s : RVSourceGroup (1)\n{\n    ui\n    {\n        string name = %s\n    }\n}\n", g, strconv.Quote(n))
+		fmt.Fprintf(&b, "\n%s_source : RVFileSource (1)\n{\n    media\n    {\n        string movie = %s\n    }\n}\n", g, strconv.Quote(movie))
+		i++
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteShell writes shell variable assignments of a sequence,
+// which could be sourced by sh or bash scripts.
+//
+// The variables are SEQ_NAME, SEQ_FIRST, SEQ_LAST, SEQ_PAD
+// and SEQ_PATTERN, which is printf style pattern like "img.%04d.exr".
+// It returns ErrSeqNotFound when the manager does not have the sequence.
+func (m *Manager) WriteShell(w io.Writer, name string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.Seqs[name]
+	if !ok {
+		return ErrSeqNotFound
+	}
+	min, max, _ := s.bounds()
+	k := m.keys[name]
+	_, err := fmt.Fprintf(w, "SEQ_NAME=%s\nSEQ_FIRST=%d\nSEQ_LAST=%d\nSEQ_PAD=%d\nSEQ_PATTERN=%s\n",
+		shellQuote(name), min, max, k.Pad, shellQuote(k.Format(FmtPercentD)))
+	return err
+}
+
+// shellQuote quotes a string with single quotes for shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
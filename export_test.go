@@ -0,0 +1,113 @@
+package sequence
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNukeFrameRanges(t *testing.T) {
+	s := NewSeq()
+	for _, f := range []int{1, 2, 3, 4, 6, 98, 99, 100} {
+		s.AddFrame(f)
+	}
+	s.SetGapTolerance(1)
+	if got, want := s.NukeFrameRanges(), "1-4 6 98-100"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got, want := s.String(), "1-6 98-100"; got != want {
+		t.Fatalf("gap tolerance changed - got: %q, want: %q", got, want)
+	}
+
+	s, _ = ParseSeq("-5..-1 -2..1 10-18x4 20")
+	if got, want := s.NukeFrameRanges(), "-5-1 10-18x4 20"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	s, _ = ParseSeq("-9..-7 -3")
+	if got, want := s.NukeFrameRanges(), "-9--7 -3"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestWriteRV(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"/a/img.0001.exr", "/a/img.0003.exr", "/a/b.1.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	var b strings.Builder
+	if err := man.WriteRV(&b); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := `GTOa (4)
+
+rv : RVSession (4)
+{
+    session
+    {
+        string viewNode = "defaultSequence"
+    }
+}
+
+sourceGroup000000 : RVSourceGroup (1)
+{
+    ui
+    {
+        string name = "/a/b.#.exr"
+    }
+}
+
+sourceGroup000000_source : RVFileSource (1)
+{
+    media
+    {
+        string movie = "/a/b.1-1@.exr"
+    }
+}
+
+sourceGroup000001 : RVSourceGroup (1)
+{
+    ui
+    {
+        string name = "/a/img.####.exr"
+    }
+}
+
+sourceGroup000001_source : RVFileSource (1)
+{
+    media
+    {
+        string movie = "/a/img.1-3@@@@.exr"
+    }
+}
+`
+	if got := b.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteShell(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"/a/it's.0011.exr", "/a/it's.0012.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	var b strings.Builder
+	if err := man.WriteShell(&b, "/a/it's.####.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := `SEQ_NAME='/a/it'\''s.####.exr'
+SEQ_FIRST=11
+SEQ_LAST=12
+SEQ_PAD=4
+SEQ_PATTERN='/a/it'\''s.%04d.exr'
+`
+	if got := b.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if err := man.WriteShell(&b, "/a/none.####.exr"); !errors.Is(err, ErrSeqNotFound) {
+		t.Fatalf("got err: %v, want: %v", err, ErrSeqNotFound)
+	}
+}
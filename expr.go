@@ -0,0 +1,216 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+var ErrInvalidExpr = errors.New("invalid frame expression")
+
+// EvalFrameExpr evaluates a frame expression and returns the result frames.
+//
+// An operand is a frame ("300"), a range ("1-200" or "1-199x2"), a name in env ("A")
+// or a parenthesized expression.
+// Operators are union ("+", "|", "∪"), minus ("-", "∖")
+// and intersect ("&", "∩"). Intersect binds tighter than the others,
+// and operators of the same precedence are evaluated from left to right.
+//
+// Note: A range should not have spaces around it's dash, like "1-200".
+// When it has, the dash will be treated as minus operator.
+//
+// The sequences in env are not modified.
+func EvalFrameExpr(expr string, env map[string]*Seq) (*Seq, error) {
+	toks, err := lexFrameExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{toks: toks, env: env}
+	s, err := p.parseUnion()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpr, p.toks[p.pos].text)
+	}
+	return s, nil
+}
+
+type exprTokKind int
+
+const (
+	tokRange exprTokKind = iota
+	tokName
+	tokUnion
+	tokMinus
+	tokIntersect
+	tokOpen
+	tokClose
+)
+
+type exprTok struct {
+	kind           exprTokKind
+	text           string
+	min, max, step int
+}
+
+// lexFrameExpr splits a frame expression into tokens.
+func lexFrameExpr(expr string) ([]exprTok, error) {
+	rs := []rune(expr)
+	toks := []exprTok{}
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '+' || r == '|' || r == '∪':
+			toks = append(toks, exprTok{kind: tokUnion, text: string(r)})
+			i++
+		case r == '-' || r == '∖':
+			toks = append(toks, exprTok{kind: tokMinus, text: string(r)})
+			i++
+		case r == '&' || r == '∩':
+			toks = append(toks, exprTok{kind: tokIntersect, text: string(r)})
+			i++
+		case r == '(':
+			toks = append(toks, exprTok{kind: tokOpen, text: "("})
+			i++
+		case r == ')':
+			toks = append(toks, exprTok{kind: tokClose, text: ")"})
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(rs) && unicode.IsDigit(rs[i]) {
+				i++
+			}
+			min, _ := strconv.Atoi(string(rs[start:i]))
+			max := min
+			if i+1 < len(rs) && rs[i] == '-' && unicode.IsDigit(rs[i+1]) {
+				i++
+				s := i
+				for i < len(rs) && unicode.IsDigit(rs[i]) {
+					i++
+				}
+				max, _ = strconv.Atoi(string(rs[s:i]))
+				if max < min {
+					return nil, fmt.Errorf("%w: reversed range %q", ErrInvalidExpr, string(rs[start:i]))
+				}
+			}
+			step := 1
+			if max != min && i+1 < len(rs) && rs[i] == 'x' && unicode.IsDigit(rs[i+1]) {
+				i++
+				s := i
+				for i < len(rs) && unicode.IsDigit(rs[i]) {
+					i++
+				}
+				step, _ = strconv.Atoi(string(rs[s:i]))
+				if step < 1 {
+					return nil, fmt.Errorf("%w: invalid step %q", ErrInvalidExpr, string(rs[start:i]))
+				}
+			}
+			toks = append(toks, exprTok{kind: tokRange, text: string(rs[start:i]), min: min, max: max, step: step})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			toks = append(toks, exprTok{kind: tokName, text: string(rs[start:i])})
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpr, string(r))
+		}
+	}
+	return toks, nil
+}
+
+// exprParser is a recursive descent parser for frame expressions.
+// It evaluates the expression while parsing it.
+type exprParser struct {
+	toks []exprTok
+	pos  int
+	env  map[string]*Seq
+}
+
+func (p *exprParser) peek() (exprTok, bool) {
+	if p.pos >= len(p.toks) {
+		return exprTok{}, false
+	}
+	return p.toks[p.pos], true
+}
+
+// parseUnion parses union and minus operations.
+func (p *exprParser) parseUnion() (*Seq, error) {
+	s, err := p.parseIntersect()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.peek()
+		if !ok || (t.kind != tokUnion && t.kind != tokMinus) {
+			return s, nil
+		}
+		p.pos++
+		o, err := p.parseIntersect()
+		if err != nil {
+			return nil, err
+		}
+		if t.kind == tokUnion {
+			s = s.Union(o)
+		} else {
+			s = s.Subtract(o)
+		}
+	}
+}
+
+// parseIntersect parses intersect operations.
+func (p *exprParser) parseIntersect() (*Seq, error) {
+	s, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokIntersect {
+			return s, nil
+		}
+		p.pos++
+		o, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		s = s.Intersect(o)
+	}
+}
+
+// parseOperand parses a frame, range, name or parenthesized expression.
+func (p *exprParser) parseOperand() (*Seq, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected end", ErrInvalidExpr)
+	}
+	p.pos++
+	switch t.kind {
+	case tokRange:
+		s := NewSeq()
+		s.frames.addRange(t.min, t.max, t.step)
+		return s, nil
+	case tokName:
+		e, ok := p.env[t.text]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown name %q", ErrInvalidExpr, t.text)
+		}
+		return e.Clone(), nil
+	case tokOpen:
+		s, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		c, ok := p.peek()
+		if !ok || c.kind != tokClose {
+			return nil, fmt.Errorf("%w: missing %q", ErrInvalidExpr, ")")
+		}
+		p.pos++
+		return s, nil
+	}
+	return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpr, t.text)
+}
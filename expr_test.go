@@ -0,0 +1,53 @@
+package sequence
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEvalFrameExpr(t *testing.T) {
+	a := NewSeq()
+	for f := 1001; f <= 1050; f++ {
+		a.AddFrame(f)
+	}
+	b := NewSeq()
+	for f := 1080; f <= 1200; f++ {
+		b.AddFrame(f)
+	}
+	env := map[string]*Seq{"A": a, "B": b}
+
+	cases := []struct {
+		expr    string
+		want    string
+		wantErr error
+	}{
+		{expr: "1-200 - 50-60 + 300", want: "1-49 61-200 300"},
+		{expr: "1-10 & 5-20", want: "5-10"},
+		{expr: "1-5 + 10-20 ∩ 15-30", want: "1-5 15-20"},
+		{expr: "(1-5 + 10-20) ∩ 3-15", want: "3-5 10-15"},
+		{expr: "(A ∪ B) ∩ 1001-1100", want: "1001-1050 1080-1100"},
+		{expr: "A - 1010-1050", want: "1001-1009"},
+		{expr: "1-10 - 1-10", want: ""},
+		{expr: "1-20x2 & 5-10", want: "5-9x2"},
+		{expr: "C + 1", wantErr: ErrInvalidExpr},
+		{expr: "(1-10", wantErr: ErrInvalidExpr},
+		{expr: "1-10 +", wantErr: ErrInvalidExpr},
+		{expr: "10-1", wantErr: ErrInvalidExpr},
+		{expr: "1-10 * 2", wantErr: ErrInvalidExpr},
+	}
+	for _, c := range cases {
+		got, err := EvalFrameExpr(c.expr, env)
+		if !errors.Is(err, c.wantErr) {
+			t.Fatalf("%q: got err: %v, want: %v", c.expr, err, c.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if got.String() != c.want {
+			t.Fatalf("%q: got: %q, want: %q", c.expr, got, c.want)
+		}
+	}
+	if a.String() != "1001-1050" {
+		t.Fatalf("env sequence modified: %q", a)
+	}
+}
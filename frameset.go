@@ -0,0 +1,329 @@
+package sequence
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"sort"
+)
+
+// span is a contiguous run of frames, which includes max.
+type span struct {
+	min, max int
+}
+
+// frameSet is a set of frames stored as sorted, non-adjacent spans.
+//
+// A rendered sequence is mostly contiguous, so it takes a few spans
+// instead of memory for every frame. A zero frameSet is an empty set.
+type frameSet struct {
+	spans []span
+	n     int
+}
+
+// len returns the number of frames in the span.
+func (sp span) len() int {
+	return sp.max - sp.min + 1
+}
+
+// search returns index of the first span that ends at or after f.
+func (fs *frameSet) search(f int) int {
+	return sort.Search(len(fs.spans), func(i int) bool { return fs.spans[i].max >= f })
+}
+
+// has reports whether the set has the frame.
+func (fs *frameSet) has(f int) bool {
+	i := fs.search(f)
+	return i < len(fs.spans) && fs.spans[i].min <= f
+}
+
+// add adds a frame. It returns false when the set already has it.
+func (fs *frameSet) add(f int) bool {
+	i := fs.search(f)
+	if i < len(fs.spans) && fs.spans[i].min <= f {
+		return false
+	}
+	fs.n++
+	left := i > 0 && fs.spans[i-1].max == f-1
+	right := i < len(fs.spans) && fs.spans[i].min == f+1
+	switch {
+	case left && right:
+		fs.spans[i-1].max = fs.spans[i].max
+		fs.spans = append(fs.spans[:i], fs.spans[i+1:]...)
+	case left:
+		fs.spans[i-1].max = f
+	case right:
+		fs.spans[i].min = f
+	default:
+		fs.spans = append(fs.spans, span{})
+		copy(fs.spans[i+1:], fs.spans[i:])
+		fs.spans[i] = span{f, f}
+	}
+	return true
+}
+
+// remove removes a frame. It returns false when the set does not have it.
+func (fs *frameSet) remove(f int) bool {
+	i := fs.search(f)
+	if i == len(fs.spans) || fs.spans[i].min > f {
+		return false
+	}
+	fs.n--
+	sp := fs.spans[i]
+	switch {
+	case sp.min == sp.max:
+		fs.spans = append(fs.spans[:i], fs.spans[i+1:]...)
+	case f == sp.min:
+		fs.spans[i].min++
+	case f == sp.max:
+		fs.spans[i].max--
+	default:
+		fs.spans = append(fs.spans, span{})
+		copy(fs.spans[i+1:], fs.spans[i:])
+		fs.spans[i].max = f - 1
+		fs.spans[i+1].min = f + 1
+	}
+	return true
+}
+
+// len returns the number of frames.
+func (fs *frameSet) len() int {
+	return fs.n
+}
+
+// bounds returns the smallest and biggest frames.
+// It returns false when the set is empty.
+func (fs *frameSet) bounds() (min, max int, ok bool) {
+	if len(fs.spans) == 0 {
+		return 0, 0, false
+	}
+	return fs.spans[0].min, fs.spans[len(fs.spans)-1].max, true
+}
+
+// all returns an iterator over the frames in ascending order.
+func (fs *frameSet) all() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, sp := range fs.spans {
+			// Not f <= sp.max, which never ends when sp.max is math.MaxInt.
+			for f := sp.min; ; f++ {
+				if !yield(f) {
+					return
+				}
+				if f == sp.max {
+					break
+				}
+			}
+		}
+	}
+}
+
+// clone returns a copy of the set.
+func (fs *frameSet) clone() frameSet {
+	return frameSet{spans: append([]span(nil), fs.spans...), n: fs.n}
+}
+
+// addSpan adds frames from lo to hi, merging the spans it touches.
+func (fs *frameSet) addSpan(lo, hi int) {
+	// Spans those end at lo-1 or start at hi+1 are merged as well.
+	before, after := lo, hi
+	if before != math.MinInt {
+		before--
+	}
+	if after != math.MaxInt {
+		after++
+	}
+	i := sort.Search(len(fs.spans), func(i int) bool { return fs.spans[i].max >= before })
+	j := sort.Search(len(fs.spans), func(i int) bool { return fs.spans[i].min > after })
+	sp := span{lo, hi}
+	for _, o := range fs.spans[i:j] {
+		fs.n -= o.len()
+		sp.min = min(sp.min, o.min)
+		sp.max = max(sp.max, o.max)
+	}
+	fs.n += sp.len()
+	fs.spans = slices.Replace(fs.spans, i, j, sp)
+}
+
+// addRange adds frames from lo to hi on every step.
+func (fs *frameSet) addRange(lo, hi, step int) {
+	if step <= 1 {
+		fs.addSpan(lo, hi)
+		return
+	}
+	for f := lo; ; f += step {
+		fs.add(f)
+		// hi-f could overflow int, but not uint as hi >= f.
+		if uint(hi-f) < uint(step) {
+			return
+		}
+	}
+}
+
+// appendSpan appends a span that does not start before the last span,
+// merging it into the last span when they overlap or touch.
+func (fs *frameSet) appendSpan(sp span) {
+	if k := len(fs.spans) - 1; k >= 0 {
+		last := &fs.spans[k]
+		if last.max == math.MaxInt || sp.min <= last.max+1 {
+			if sp.max > last.max {
+				fs.n += sp.max - last.max
+				last.max = sp.max
+			}
+			return
+		}
+	}
+	fs.spans = append(fs.spans, sp)
+	fs.n += sp.len()
+}
+
+// union returns a set that has frames of fs or o.
+func (fs *frameSet) union(o *frameSet) frameSet {
+	u := frameSet{}
+	i, j := 0, 0
+	for i < len(fs.spans) || j < len(o.spans) {
+		if j == len(o.spans) || (i < len(fs.spans) && fs.spans[i].min <= o.spans[j].min) {
+			u.appendSpan(fs.spans[i])
+			i++
+		} else {
+			u.appendSpan(o.spans[j])
+			j++
+		}
+	}
+	return u
+}
+
+// intersect returns a set that has frames of both fs and o.
+func (fs *frameSet) intersect(o *frameSet) frameSet {
+	x := frameSet{}
+	i, j := 0, 0
+	for i < len(fs.spans) && j < len(o.spans) {
+		a, b := fs.spans[i], o.spans[j]
+		if lo, hi := max(a.min, b.min), min(a.max, b.max); lo <= hi {
+			x.appendSpan(span{lo, hi})
+		}
+		if a.max < b.max {
+			i++
+		} else {
+			j++
+		}
+	}
+	return x
+}
+
+// subtract returns a set that has frames of fs but not of o.
+func (fs *frameSet) subtract(o *frameSet) frameSet {
+	d := frameSet{}
+	j := 0
+	for _, a := range fs.spans {
+		for j < len(o.spans) && o.spans[j].max < a.min {
+			j++
+		}
+		lo, covered := a.min, false
+		for k := j; k < len(o.spans) && o.spans[k].min <= a.max; k++ {
+			b := o.spans[k]
+			if b.min > lo {
+				d.appendSpan(span{lo, b.min - 1})
+			}
+			if b.max >= a.max {
+				covered = true
+				break
+			}
+			lo = b.max + 1
+		}
+		if !covered {
+			d.appendSpan(span{lo, a.max})
+		}
+	}
+	return d
+}
+
+// clip returns a set that has frames of fs from lo to hi.
+func (fs *frameSet) clip(lo, hi int) frameSet {
+	if lo > hi {
+		return frameSet{}
+	}
+	return fs.intersect(&frameSet{spans: []span{{lo, hi}}})
+}
+
+// complement returns a set that has frames from lo to hi those fs does not have.
+func (fs *frameSet) complement(lo, hi int) frameSet {
+	if lo > hi {
+		return frameSet{}
+	}
+	full := frameSet{spans: []span{{lo, hi}}, n: span{lo, hi}.len()}
+	return full.subtract(fs)
+}
+
+// steppedRanges converts the set to ranges with constant strides.
+//
+// Like walking the frames one by one, a run of frames 1 apart
+// continues to the end of a span, and a run of single frame spans
+// with a same stride, which could end at the first frame of a span,
+// becomes a stepped range when it has at least 3 frames.
+func (fs *frameSet) steppedRanges() []*Range {
+	rngs := []*Range{}
+	k := 0
+	if len(fs.spans) == 0 {
+		return rngs
+	}
+	f := fs.spans[0].min
+	for k < len(fs.spans) {
+		sp := fs.spans[k]
+		if f < sp.max {
+			rngs = append(rngs, &Range{Min: f, Max: sp.max})
+			k++
+			if k < len(fs.spans) {
+				f = fs.spans[k].min
+			}
+			continue
+		}
+		if k+1 == len(fs.spans) {
+			rngs = append(rngs, NewRange(f))
+			break
+		}
+		step := fs.spans[k+1].min - f
+		j, n := k+1, 2
+		for fs.spans[j].min == fs.spans[j].max && j+1 < len(fs.spans) && fs.spans[j+1].min-fs.spans[j].max == step {
+			j++
+			n++
+		}
+		if n < 3 {
+			rngs = append(rngs, NewRange(f))
+			k++
+			f = fs.spans[k].min
+			continue
+		}
+		last := fs.spans[j].min
+		rngs = append(rngs, &Range{Min: f, Max: last, Step: step})
+		if fs.spans[j].max > last {
+			k, f = j, last+1
+		} else {
+			k = j + 1
+			if k < len(fs.spans) {
+				f = fs.spans[k].min
+			}
+		}
+	}
+	return rngs
+}
+
+// contiguousRanges converts the set to contiguous ranges.
+// Gaps up to tolerance missing frames are bridged over.
+func (fs *frameSet) contiguousRanges(tolerance int) []*Range {
+	rngs := []*Range{}
+	for i, sp := range fs.spans {
+		if i > 0 && bridged(fs.spans[i-1], sp, tolerance) {
+			rngs[len(rngs)-1].Max = sp.max
+			continue
+		}
+		rngs = append(rngs, &Range{Min: sp.min, Max: sp.max})
+	}
+	return rngs
+}
+
+// bridged reports whether the gap between spans a and b,
+// where b is after a, is not bigger than tolerance missing frames.
+func bridged(a, b span, tolerance int) bool {
+	// b.min-a.max could overflow int, but not uint.
+	return uint(b.min-a.max)-1 <= uint(tolerance)
+}
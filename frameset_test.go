@@ -0,0 +1,232 @@
+package sequence
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestFrameSet(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var fs frameSet
+	want := make(map[int]bool)
+	for i := 0; i < 5000; i++ {
+		f := r.Intn(200) - 20
+		if r.Intn(3) == 0 {
+			if got := fs.remove(f); got != want[f] {
+				t.Fatalf("remove %d - got: %v, want: %v", f, got, want[f])
+			}
+			delete(want, f)
+		} else {
+			if got := fs.add(f); got == want[f] {
+				t.Fatalf("add %d - got: %v, want: %v", f, got, !want[f])
+			}
+			want[f] = true
+		}
+	}
+	frames := []int{}
+	for f := range want {
+		frames = append(frames, f)
+	}
+	sort.Ints(frames)
+	got := []int{}
+	for f := range fs.all() {
+		got = append(got, f)
+	}
+	if !reflect.DeepEqual(got, frames) {
+		t.Fatalf("got: %v, want: %v", got, frames)
+	}
+	if fs.len() != len(frames) {
+		t.Fatalf("got len: %d, want: %d", fs.len(), len(frames))
+	}
+	for i := 1; i < len(fs.spans); i++ {
+		if fs.spans[i].min <= fs.spans[i-1].max+1 {
+			t.Fatalf("spans are not merged: %v %v", fs.spans[i-1], fs.spans[i])
+		}
+	}
+}
+
+func TestFrameSetSpans(t *testing.T) {
+	s := NewSeq()
+	for f := 1; f <= 100000; f++ {
+		s.AddFrame(f)
+	}
+	if got := len(s.frames.spans); got != 1 {
+		t.Fatalf("got: %d spans, want: 1", got)
+	}
+	s.RemoveFrame(500)
+	if got := len(s.frames.spans); got != 2 {
+		t.Fatalf("got: %d spans, want: 2", got)
+	}
+	if got, want := s.String(), "1-499 501-100000"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
+
+// randomSet returns a random frame set and it's frames as a map.
+func randomSet(r *rand.Rand) (frameSet, map[int]bool) {
+	var fs frameSet
+	frames := make(map[int]bool)
+	n := r.Intn(60)
+	for i := 0; i < n; i++ {
+		f := r.Intn(80) - 10
+		if r.Intn(4) == 0 {
+			w := r.Intn(10)
+			fs.addSpan(f, f+w)
+			for g := f; g <= f+w; g++ {
+				frames[g] = true
+			}
+			continue
+		}
+		fs.add(f)
+		frames[f] = true
+	}
+	return fs, frames
+}
+
+// setFrames returns sorted frames of a set, checking it's spans.
+func setFrames(t *testing.T, fs frameSet) []int {
+	for i := 1; i < len(fs.spans); i++ {
+		if fs.spans[i].min <= fs.spans[i-1].max+1 {
+			t.Fatalf("spans are not merged: %v %v", fs.spans[i-1], fs.spans[i])
+		}
+	}
+	frames := []int{}
+	for f := range fs.all() {
+		frames = append(frames, f)
+	}
+	if fs.len() != len(frames) {
+		t.Fatalf("got len: %d, want: %d", fs.len(), len(frames))
+	}
+	return frames
+}
+
+// perFrameRanges is the frame by frame version of frameSet.steppedRanges.
+func perFrameRanges(frames []int) []*Range {
+	rngs := []*Range{}
+	for i := 0; i < len(frames); {
+		j := i
+		step := 1
+		if i+1 < len(frames) {
+			step = frames[i+1] - frames[i]
+			j = i + 1
+			for j+1 < len(frames) && frames[j+1]-frames[j] == step {
+				j++
+			}
+		}
+		n := j - i + 1
+		if n == 1 || (step != 1 && n < 3) {
+			rngs = append(rngs, NewRange(frames[i]))
+			i++
+			continue
+		}
+		r := &Range{Min: frames[i], Max: frames[j]}
+		if step != 1 {
+			r.Step = step
+		}
+		rngs = append(rngs, r)
+		i = j + 1
+	}
+	return rngs
+}
+
+func TestFrameSetOps(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	sorted := func(frames map[int]bool, keep func(f int) bool) []int {
+		fs := []int{}
+		for f := range frames {
+			if keep(f) {
+				fs = append(fs, f)
+			}
+		}
+		sort.Ints(fs)
+		return fs
+	}
+	for i := 0; i < 2000; i++ {
+		a, am := randomSet(r)
+		b, bm := randomSet(r)
+		all := make(map[int]bool)
+		for f := range am {
+			all[f] = true
+		}
+		for f := range bm {
+			all[f] = true
+		}
+		if got, want := setFrames(t, a.union(&b)), sorted(all, func(int) bool { return true }); !reflect.DeepEqual(got, want) {
+			t.Fatalf("union - got: %v, want: %v", got, want)
+		}
+		if got, want := setFrames(t, a.intersect(&b)), sorted(am, func(f int) bool { return bm[f] }); !reflect.DeepEqual(got, want) {
+			t.Fatalf("intersect - got: %v, want: %v", got, want)
+		}
+		if got, want := setFrames(t, a.subtract(&b)), sorted(am, func(f int) bool { return !bm[f] }); !reflect.DeepEqual(got, want) {
+			t.Fatalf("subtract - got: %v, want: %v", got, want)
+		}
+		if got, want := setFrames(t, a.clip(0, 30)), sorted(am, func(f int) bool { return f >= 0 && f <= 30 }); !reflect.DeepEqual(got, want) {
+			t.Fatalf("clip - got: %v, want: %v", got, want)
+		}
+		if got, want := setFrames(t, a.complement(-5, 40)), sorted(func() map[int]bool {
+			m := make(map[int]bool)
+			for f := -5; f <= 40; f++ {
+				m[f] = true
+			}
+			return m
+		}(), func(f int) bool { return !am[f] }); !reflect.DeepEqual(got, want) {
+			t.Fatalf("complement - got: %v, want: %v", got, want)
+		}
+		frames := setFrames(t, a)
+		if got, want := a.steppedRanges(), perFrameRanges(frames); !reflect.DeepEqual(got, want) {
+			t.Fatalf("steppedRanges of %v - got: %v, want: %v", frames, got, want)
+		}
+	}
+}
+
+func TestFrameSetLarge(t *testing.T) {
+	s, err := ParseSeq("0-999999999999")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := s.String(), "0-999999999999"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got := s.Len(); got != 1000000000000 {
+		t.Fatalf("got len: %d", got)
+	}
+	other, _ := ParseSeq("500-600")
+	if got, want := s.Subtract(other).String(), "0-499 601-999999999999"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got, want := s.Intersect(other).String(), "500-600"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got, want := s.Subtract(other).Gaps()[0].String(), "500-600"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	parts := SplitAt(s, []int{10})
+	if got, want := parts[1].String(), "10-999999999999"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	// Stepping near the biggest frame should stop.
+	max := strconv.Itoa(math.MaxInt)
+	s, err = ParseSeq(strconv.Itoa(math.MaxInt-4) + "-" + max + "x3")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got := s.Len(); got != 2 {
+		t.Fatalf("got len: %d, want: 2", got)
+	}
+	s, err = EvalFrameExpr(strconv.Itoa(math.MaxInt-1)+"-"+max, nil)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	n := 0
+	for range s.All() {
+		n++
+	}
+	if n != 2 {
+		t.Fatalf("got: %d frames, want: 2", n)
+	}
+}
@@ -0,0 +1,58 @@
+package fsops
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kybin/sequence"
+)
+
+// Copy copies files of renames, from each From to it's To.
+// The files keep their mode bits.
+// Use Manager.CopyPlan to get the copies of a sequence.
+//
+// It returns ErrTargetExists and copies nothing,
+// when a target is an existing file. When a copy fails,
+// it removes the files already copied.
+func Copy(renames []sequence.Rename) error {
+	for _, r := range renames {
+		if _, err := os.Lstat(r.To); err == nil {
+			return fmt.Errorf("%w: %s", ErrTargetExists, r.To)
+		}
+	}
+	for i, r := range renames {
+		if err := copyFile(r.From, r.To); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				os.Remove(renames[j].To)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies a file to a new file.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(dst)
+	}
+	return err
+}
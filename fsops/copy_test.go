@@ -0,0 +1,42 @@
+package fsops
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kybin/sequence"
+)
+
+func TestCopy(t *testing.T) {
+	dir := t.TempDir()
+	srcs := touch(t, dir, "img.0001.exr", "img.0002.exr")
+	renames := []sequence.Rename{
+		{From: srcs[0], To: filepath.Join(dir, "copy.0001.exr")},
+		{From: srcs[1], To: filepath.Join(dir, "copy.0002.exr")},
+	}
+	if err := Copy(renames); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := []string{"copy.0001.exr", "copy.0002.exr", "img.0001.exr", "img.0002.exr"}
+	if got := dirNames(t, dir); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "copy.0002.exr"))
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if string(data) != "img.0002.exr" {
+		t.Fatalf("got content: %q, want: %q", data, "img.0002.exr")
+	}
+
+	renames[0].To = filepath.Join(dir, "again.0001.exr")
+	if err := Copy(renames); !errors.Is(err, ErrTargetExists) {
+		t.Fatalf("got err: %v, want: %v", err, ErrTargetExists)
+	}
+	if got := dirNames(t, dir); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+// Package fsops has operations on sequence files on disk,
+// kept apart from the sequence package which only groups names.
+package fsops
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/kybin/sequence"
+)
+
+var ErrLocked = errors.New("already locked")
+
+// A Lock is an advisory lock held by a lock file.
+//
+// It only works between programs those use lock files of this package.
+type Lock struct {
+	path string
+}
+
+// LockFrame locks a frame of the key's sequence,
+// so other writers could know the frame is being written.
+// The lock file is the frame's file name with ".lock" suffix.
+//
+// It returns ErrLocked when the frame is already locked.
+func LockFrame(k sequence.Key, f int) (*Lock, error) {
+	return lockFile(k.FileName(f) + ".lock")
+}
+
+// LockSeq locks a whole sequence of the key.
+// The lock file is the sequence name formatted by FmtSharp,
+// with ".lock" suffix.
+//
+// It returns ErrLocked when the sequence is already locked.
+// Note that it does not check locks of each frame.
+func LockSeq(k sequence.Key) (*Lock, error) {
+	return lockFile(k.Format(sequence.FmtSharp) + ".lock")
+}
+
+// lockFile creates a lock file exclusively,
+// and writes the process id into it for debugging.
+func lockFile(path string) (*Lock, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("%w: %s", ErrLocked, path)
+		}
+		return nil, err
+	}
+	host, _ := os.Hostname()
+	_, err = fmt.Fprintf(f, "%s %d\n", host, os.Getpid())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	return &Lock{path: path}, nil
+}
+
+// Path returns the lock file path.
+func (l *Lock) Path() string {
+	return l.path
+}
+
+// Unlock releases the lock by removing the lock file.
+func (l *Lock) Unlock() error {
+	return os.Remove(l.path)
+}
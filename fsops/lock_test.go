@@ -0,0 +1,51 @@
+package fsops
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/kybin/sequence"
+)
+
+func TestLockFrame(t *testing.T) {
+	k := sequence.Key{Pre: filepath.Join(t.TempDir(), "img."), Pad: 4, Post: ".exr"}
+	l, err := LockFrame(k, 1)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := l.Path(), k.Pre+"0001.exr.lock"; got != want {
+		t.Fatalf("got path: %q, want: %q", got, want)
+	}
+	if _, err := LockFrame(k, 1); !errors.Is(err, ErrLocked) {
+		t.Fatalf("got err: %v, want: %v", err, ErrLocked)
+	}
+	other, err := LockFrame(k, 2)
+	if err != nil {
+		t.Fatalf("other frame should be lockable: %v", err)
+	}
+	other.Unlock()
+	if err := l.Unlock(); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	l, err = LockFrame(k, 1)
+	if err != nil {
+		t.Fatalf("unlocked frame should be lockable: %v", err)
+	}
+	l.Unlock()
+}
+
+func TestLockSeq(t *testing.T) {
+	k := sequence.Key{Pre: filepath.Join(t.TempDir(), "img."), Pad: 4, Post: ".exr"}
+	l, err := LockSeq(k)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	defer l.Unlock()
+	if got, want := l.Path(), k.Pre+"####.exr.lock"; got != want {
+		t.Fatalf("got path: %q, want: %q", got, want)
+	}
+	if _, err := LockSeq(k); !errors.Is(err, ErrLocked) {
+		t.Fatalf("got err: %v, want: %v", err, ErrLocked)
+	}
+}
@@ -0,0 +1,89 @@
+package fsops
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/kybin/sequence"
+)
+
+var ErrTargetExists = errors.New("target file exists")
+
+// renameTmp is the suffix of temporary file names while renaming.
+const renameTmp = ".rename-tmp"
+
+// Renumber renames the files of a sequence on disk to a new start frame and step
+// with Rename, then renumbers the sequence in the manager. See Manager.Renumber.
+//
+// The sequence should not be changed by others while renumbering.
+func Renumber(m *sequence.Manager, name string, start, step int) error {
+	renames, err := m.RenumberPlan(name, start, step)
+	if err != nil {
+		return err
+	}
+	if err := Rename(renames); err != nil {
+		return err
+	}
+	return m.Renumber(name, start, step)
+}
+
+// Respace renames the files of a sequence rendered on the old stride
+// to a new start frame and step with Rename, then respaces the sequence
+// in the manager. See Manager.Respace.
+//
+// The sequence should not be changed by others while respacing.
+func Respace(m *sequence.Manager, name string, start, stride, step int) error {
+	renames, err := m.RespacePlan(name, start, stride, step)
+	if err != nil {
+		return err
+	}
+	if err := Rename(renames); err != nil {
+		return err
+	}
+	return m.Respace(name, start, stride, step)
+}
+
+// Rename applies renames in two phases through temporary names,
+// so sources and targets could overlap.
+//
+// It returns ErrTargetExists and renames nothing, when a target is
+// an existing file other than the sources. When a rename fails,
+// it tries to rename the files back.
+func Rename(renames []sequence.Rename) error {
+	srcs := make(map[string]bool, len(renames))
+	for _, r := range renames {
+		srcs[r.From] = true
+	}
+	for _, r := range renames {
+		for _, p := range []string{r.To, r.From + renameTmp} {
+			if p == r.To && srcs[p] {
+				continue
+			}
+			if _, err := os.Lstat(p); err == nil {
+				return fmt.Errorf("%w: %s", ErrTargetExists, p)
+			}
+		}
+	}
+
+	for i, r := range renames {
+		if err := os.Rename(r.From, r.From+renameTmp); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				os.Rename(renames[j].From+renameTmp, renames[j].From)
+			}
+			return err
+		}
+	}
+	for i, r := range renames {
+		if err := os.Rename(r.From+renameTmp, r.To); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				os.Rename(renames[j].To, renames[j].From+renameTmp)
+			}
+			for j := len(renames) - 1; j >= 0; j-- {
+				os.Rename(renames[j].From+renameTmp, renames[j].From)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package fsops
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/kybin/sequence"
+)
+
+// touch creates empty files in dir, and returns their paths.
+func touch(t *testing.T, dir string, names ...string) []string {
+	paths := []string{}
+	for _, n := range names {
+		p := filepath.Join(dir, n)
+		if err := os.WriteFile(p, []byte(n), 0644); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// dirNames returns sorted file names in dir.
+func dirNames(t *testing.T, dir string) []string {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	names := []string{}
+	for _, e := range ents {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRenumber(t *testing.T) {
+	cases := []struct {
+		files       []string
+		start, step int
+		want        []string
+		wantFrames  string
+	}{
+		{
+			files:      []string{"img.1001.exr", "img.1002.exr", "img.1004.exr"},
+			start:      1,
+			step:       1,
+			want:       []string{"img.0001.exr", "img.0002.exr", "img.0004.exr"},
+			wantFrames: "1-2 4",
+		},
+		{
+			// Sources and targets overlap.
+			files:      []string{"img.0001.exr", "img.0002.exr", "img.0003.exr"},
+			start:      2,
+			step:       1,
+			want:       []string{"img.0002.exr", "img.0003.exr", "img.0004.exr"},
+			wantFrames: "2-4",
+		},
+		{
+			// Gaps are kept.
+			files:      []string{"img.0001.exr", "img.0003.exr", "img.0005.exr"},
+			start:      11,
+			step:       1,
+			want:       []string{"img.0011.exr", "img.0013.exr", "img.0015.exr"},
+			wantFrames: "11-15x2",
+		},
+		{
+			files:      []string{"img.0001.exr", "img.0002.exr", "img.0003.exr"},
+			start:      10,
+			step:       2,
+			want:       []string{"img.0010.exr", "img.0012.exr", "img.0014.exr"},
+			wantFrames: "10-14x2",
+		},
+	}
+	for _, c := range cases {
+		dir := t.TempDir()
+		man := sequence.NewManager(sequence.DefaultSplitter, sequence.FmtSharp)
+		for _, p := range touch(t, dir, c.files...) {
+			if err := man.Add(p); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+		}
+		name := filepath.Join(dir, "img.####.exr")
+		if err := Renumber(man, name, c.start, c.step); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		if got := dirNames(t, dir); !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("got: %q, want: %q", got, c.want)
+		}
+		s, _ := man.Seq(name)
+		if got := s.String(); got != c.wantFrames {
+			t.Fatalf("got: %q, want: %q", got, c.wantFrames)
+		}
+	}
+}
+
+func TestRenumberConflict(t *testing.T) {
+	dir := t.TempDir()
+	man := sequence.NewManager(sequence.DefaultSplitter, sequence.FmtSharp)
+	for _, p := range touch(t, dir, "img.0005.exr", "img.0006.exr") {
+		if err := man.Add(p); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	// An unmanaged file is in the way.
+	touch(t, dir, "img.0002.exr")
+	name := filepath.Join(dir, "img.####.exr")
+	if err := Renumber(man, name, 1, 1); !errors.Is(err, ErrTargetExists) {
+		t.Fatalf("got err: %v, want: %v", err, ErrTargetExists)
+	}
+	want := []string{"img.0002.exr", "img.0005.exr", "img.0006.exr"}
+	if got := dirNames(t, dir); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if err := Renumber(man, "/none/img.####.exr", 1, 1); !errors.Is(err, sequence.ErrSeqNotFound) {
+		t.Fatalf("got err: %v, want: %v", err, sequence.ErrSeqNotFound)
+	}
+	if err := Renumber(man, name, -10, 1); !errors.Is(err, sequence.ErrNegativeFrame) {
+		t.Fatalf("got err: %v, want: %v", err, sequence.ErrNegativeFrame)
+	}
+}
+
+func TestRespace(t *testing.T) {
+	dir := t.TempDir()
+	man := sequence.NewManager(sequence.DefaultSplitter, sequence.FmtSharp)
+	for _, p := range touch(t, dir, "img.1001.exr", "img.1003.exr", "img.1007.exr") {
+		if err := man.Add(p); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	name := filepath.Join(dir, "img.####.exr")
+	if err := Respace(man, name, 1, 2, 1); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := []string{"img.0001.exr", "img.0002.exr", "img.0004.exr"}
+	if got := dirNames(t, dir); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	s, _ := man.Seq(name)
+	if got, want := s.String(), "1-2 4"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if err := Respace(man, name, 1, 3, 1); !errors.Is(err, sequence.ErrInvalidRange) {
+		t.Fatalf("got err: %v, want: %v", err, sequence.ErrInvalidRange)
+	}
+}
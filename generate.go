@@ -0,0 +1,73 @@
+package sequence
+
+import (
+	"fmt"
+	"iter"
+	"math/rand"
+	"path"
+)
+
+// A GenSpec describes sequences GenerateNames generates.
+type GenSpec struct {
+	// Seed makes the generation deterministic.
+	// The same spec always generates the same names.
+	Seed int64
+
+	// Seqs is the number of sequences.
+	Seqs int
+
+	// Frames is the frame count of each sequence, before gaps.
+	Frames int
+
+	// Start is the first frame of each sequence.
+	Start int
+
+	// GapRate is the probability of a frame to be missing, from 0 to 1.
+	GapRate float64
+
+	// Root is the directory the sequences are generated in.
+	Root string
+}
+
+// genConventions are naming conventions GenerateNames mixes.
+// Each takes a base name, frame digits and an extension.
+var genConventions = []string{
+	"%s.%s.%s",
+	"%s_%s.%s",
+	"%s%s.%s",
+	"%s_v001.%s.%s",
+}
+
+var (
+	genWords = []string{"comp", "plate", "render", "beauty", "matte", "depth", "fg", "bg"}
+	genExts  = []string{"exr", "dpx", "png", "jpg", "tif"}
+	genPads  = []int{1, 3, 4, 4, 4, 5}
+)
+
+// GenerateNames returns an iterator over realistic sequence file names,
+// with mixed naming conventions, paddings and random gaps.
+// It is meant for load testing pipelines and splitters.
+//
+// Each sequence is in it's own directory under the spec's root,
+// like "root/sh003/beauty_v001.1001.exr".
+func GenerateNames(spec GenSpec) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		r := rand.New(rand.NewSource(spec.Seed))
+		for i := 0; i < spec.Seqs; i++ {
+			conv := genConventions[r.Intn(len(genConventions))]
+			word := genWords[r.Intn(len(genWords))]
+			ext := genExts[r.Intn(len(genExts))]
+			pad := genPads[r.Intn(len(genPads))]
+			dir := path.Join(spec.Root, fmt.Sprintf("sh%03d", i))
+			for f := spec.Start; f < spec.Start+spec.Frames; f++ {
+				if r.Float64() < spec.GapRate {
+					continue
+				}
+				name := fmt.Sprintf(conv, word, fmt.Sprintf("%0*d", pad, f), ext)
+				if !yield(path.Join(dir, name)) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package sequence
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestGenerateNames(t *testing.T) {
+	spec := GenSpec{Seed: 42, Seqs: 20, Frames: 100, Start: 1001, GapRate: 0.1, Root: "show"}
+	a := slices.Collect(GenerateNames(spec))
+	b := slices.Collect(GenerateNames(spec))
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("same spec generated different names")
+	}
+	if len(a) == 0 || len(a) >= 2000 {
+		t.Fatalf("got: %d names, want some gaps in 2000 frames", len(a))
+	}
+
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, n := range a {
+		if err := man.Add(n); err != nil {
+			t.Fatalf("%q: got error: %v", n, err)
+		}
+	}
+	if got := len(man.SeqNames()); got != spec.Seqs {
+		t.Fatalf("got: %d sequences, want: %d", got, spec.Seqs)
+	}
+
+	spec.Seed = 43
+	if c := slices.Collect(GenerateNames(spec)); reflect.DeepEqual(a, c) {
+		t.Fatalf("different seeds generated same names")
+	}
+}
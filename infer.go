@@ -0,0 +1,57 @@
+package sequence
+
+import "path/filepath"
+
+// An Inferred is an expected range of a sequence
+// inferred from it's sibling sequences.
+type Inferred struct {
+	// Expected is the range that covers all sequences in the directory.
+	Expected *Range
+
+	// Short is true when the sequence does not cover Expected.
+	Short bool
+}
+
+// InferExpected guesses each sequence's expected range
+// from the sequences in the same directory, like other passes or versions.
+// The expected range is the envelope of the sibling sequences.
+//
+// It is useful when there is no explicit range data for the sequences.
+func InferExpected(m *Manager) map[string]Inferred {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	envs := make(map[string]*Range)
+	for n, s := range m.Seqs {
+		min, max, ok := s.bounds()
+		if !ok {
+			continue
+		}
+		d := filepath.Dir(m.keys[n].Pre + "_")
+		r, ok := envs[d]
+		if !ok {
+			envs[d] = &Range{Min: min, Max: max}
+			continue
+		}
+		if min < r.Min {
+			r.Min = min
+		}
+		if max > r.Max {
+			r.Max = max
+		}
+	}
+
+	inf := make(map[string]Inferred)
+	for n, s := range m.Seqs {
+		min, max, ok := s.bounds()
+		if !ok {
+			continue
+		}
+		r := envs[filepath.Dir(m.keys[n].Pre+"_")]
+		inf[n] = Inferred{
+			Expected: &Range{Min: r.Min, Max: r.Max},
+			Short:    min > r.Min || max < r.Max,
+		}
+	}
+	return inf
+}
@@ -0,0 +1,35 @@
+package sequence
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestInferExpected(t *testing.T) {
+	files := map[string][2]int{
+		"/sh010/beauty.":  {1001, 1100},
+		"/sh010/diffuse.": {1001, 1080},
+		"/sh010/spec.":    {1010, 1100},
+		"/sh020/beauty.":  {1, 10},
+	}
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for pre, r := range files {
+		for f := r[0]; f <= r[1]; f++ {
+			if err := man.Add(fmt.Sprintf("%s%04d.exr", pre, f)); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+		}
+	}
+
+	got := InferExpected(man)
+	want := map[string]Inferred{
+		"/sh010/beauty.####.exr":  {Expected: &Range{Min: 1001, Max: 1100}, Short: false},
+		"/sh010/diffuse.####.exr": {Expected: &Range{Min: 1001, Max: 1100}, Short: true},
+		"/sh010/spec.####.exr":    {Expected: &Range{Min: 1001, Max: 1100}, Short: true},
+		"/sh020/beauty.####.exr":  {Expected: &Range{Min: 1, Max: 10}, Short: false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+}
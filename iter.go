@@ -0,0 +1,33 @@
+package sequence
+
+import "iter"
+
+// All returns an iterator over the frames of the sequence, in ascending order.
+func (s *Seq) All() iter.Seq[int] {
+	return s.frames.all()
+}
+
+// All returns an iterator over the sequences by their names,
+// in ascending name order.
+//
+// It iterates over a snapshot of the names, and the lock is not held
+// while yielding, so the loop body could add files to the manager.
+// The sequences should be treated as read only, like Seqs.
+func (m *Manager) All() iter.Seq2[string, *Seq] {
+	return func(yield func(string, *Seq) bool) {
+		m.mu.RLock()
+		names := m.sortedNames()
+		m.mu.RUnlock()
+		for _, n := range names {
+			m.mu.RLock()
+			s, ok := m.Seqs[n]
+			m.mu.RUnlock()
+			if !ok {
+				continue
+			}
+			if !yield(n, s) {
+				return
+			}
+		}
+	}
+}
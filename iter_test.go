@@ -0,0 +1,46 @@
+package sequence
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSeqAll(t *testing.T) {
+	s, err := ParseSeq("5 1-3 10")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	got := []int{}
+	for f := range s.All() {
+		if f > 5 {
+			break
+		}
+		got = append(got, f)
+	}
+	if want := []int{1, 2, 3, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestManagerAll(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"/b/img.0001.exr", "/a/img.0001.exr", "/a/img.0002.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	got := []string{}
+	for n, s := range man.All() {
+		got = append(got, n+" "+s.String())
+		// Adding files while iterating should not dead lock,
+		// and does not change the names being iterated.
+		if n == "/a/img.####.exr" {
+			if err := man.Add("/c/img.0001.exr"); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+		}
+	}
+	if want := []string{"/a/img.####.exr 1-2", "/b/img.####.exr 1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,122 @@
+package sequence
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// MarshalJSON encodes the range as a JSON string, like "1-9x2".
+func (r Range) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON decodes a JSON string made by MarshalJSON.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	p, err := ParseRange(str)
+	if err != nil {
+		return err
+	}
+	*r = *p
+	return nil
+}
+
+// MarshalJSON encodes the frames as a JSON string of exact ranges,
+// like "1-4 98-100", regardless of the gap tolerance.
+// Only the frames are encoded.
+func (s *Seq) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.exactString())
+}
+
+// UnmarshalJSON decodes a JSON string made by MarshalJSON.
+// It replaces the frames of the sequence.
+func (s *Seq) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	p, err := ParseSeq(str)
+	if err != nil {
+		return err
+	}
+	s.frames = p.frames
+	if p.allowNegative {
+		s.allowNegative = true
+	}
+	return nil
+}
+
+// jsonSeq is a sequence of a manager in JSON.
+type jsonSeq struct {
+	Name   string          `json:"name"`
+	Pre    string          `json:"pre"`
+	Pad    int             `json:"pad"`
+	Post   string          `json:"post"`
+	FPS    int             `json:"fps,omitempty"`
+	Frames *Seq            `json:"frames"`
+	Views  map[string]*Seq `json:"views,omitempty"`
+	Widths map[int]*Seq    `json:"widths,omitempty"`
+	Parts  []jsonParts     `json:"parts,omitempty"`
+}
+
+// jsonParts are original name parts of frames in JSON.
+type jsonParts struct {
+	Pre    string `json:"pre"`
+	Post   string `json:"post"`
+	Frames *Seq   `json:"frames"`
+}
+
+// MarshalJSON encodes the sequences of the manager in name order,
+// with their keys and frames.
+//
+// Frames of stereo views, frames padded unlike their sequence
+// and frames with their own name parts are also encoded,
+// so the file names of the frames could be rebuilt.
+func (m *Manager) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	seqs := make([]jsonSeq, 0, len(m.Seqs))
+	for _, n := range m.sortedNames() {
+		k := m.keys[n]
+		st := m.frameState(n)
+		js := jsonSeq{Name: n, Pre: k.Pre, Pad: k.Pad, Post: k.Post, FPS: k.FPS, Frames: m.Seqs[n], Views: st.views, Widths: st.widths}
+		for _, p := range st.sortedParts() {
+			js.Parts = append(js.Parts, jsonParts{Pre: p.pre, Post: p.post, Frames: st.parts[p]})
+		}
+		seqs = append(seqs, js)
+	}
+	return json.Marshal(map[string][]jsonSeq{"sequences": seqs})
+}
+
+// UnmarshalJSON decodes sequences made by MarshalJSON, and adds them
+// to the manager. The names are re-rendered by the manager's formatter.
+//
+// The manager should be created with NewManager, as it's splitter
+// and formatter are not encoded. Frames those could not be added
+// are returned as joined errors.
+func (m *Manager) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Sequences []jsonSeq `json:"sequences"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	errs := []error{}
+	for _, js := range v.Sequences {
+		if js.Frames == nil {
+			continue
+		}
+		k := Key{Pre: js.Pre, Pad: js.Pad, Post: js.Post, FPS: js.FPS}
+		st := frameState{views: js.Views, widths: js.Widths, parts: make(map[nameParts]*Seq)}
+		for _, p := range js.Parts {
+			st.parts[nameParts{pre: p.Pre, post: p.Post}] = p.Frames
+		}
+		errs = append(errs, m.addKey(k, js.Frames, st)...)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,158 @@
+package sequence
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRangeJSON(t *testing.T) {
+	for _, str := range []string{`"1-9x2"`, `"5"`, `"-5..-1"`} {
+		var r Range
+		if err := json.Unmarshal([]byte(str), &r); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		got, err := json.Marshal(r)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		if string(got) != str {
+			t.Fatalf("got: %s, want: %s", got, str)
+		}
+	}
+	var r Range
+	if err := json.Unmarshal([]byte(`"9-1"`), &r); !errors.Is(err, ErrInvalidRange) {
+		t.Fatalf("got err: %v, want: %v", err, ErrInvalidRange)
+	}
+}
+
+func TestSeqJSON(t *testing.T) {
+	s, err := ParseSeq("1-4 7 10-20x2")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	s.SetGapTolerance(5)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := string(data), `"1-4 7 10-20x2"`; got != want {
+		t.Fatalf("got: %s, want: %s", got, want)
+	}
+	d := NewSeq()
+	if err := json.Unmarshal(data, d); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := d.String(), "1-4 7 10-20x2"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	// Negative frames use the package's own range format.
+	s, _ = ParseSeq("-5..-2 3")
+	data, _ = json.Marshal(s)
+	if got, want := string(data), `"-5..-2 3"`; got != want {
+		t.Fatalf("got: %s, want: %s", got, want)
+	}
+	d = NewSeq()
+	if err := json.Unmarshal(data, d); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := d.String(), "-5..-2 3"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestManagerJSON(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	files := []string{
+		"/a/img.0001.exr",
+		"/a/img.0002.exr",
+		"/a/img.0005.exr",
+		"/b/plate_v001.101.dpx",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	data, err := json.Marshal(man)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := `{"sequences":[` +
+		`{"name":"/a/img.####.exr","pre":"/a/img.","pad":4,"post":".exr","frames":"1-2 5"},` +
+		`{"name":"/b/plate_v001.###.dpx","pre":"/b/plate_v001.","pad":3,"post":".dpx","frames":"101"}]}`
+	if string(data) != want {
+		t.Fatalf("got: %s, want: %s", data, want)
+	}
+
+	dec := NewManager(DefaultSplitter, FmtPercentD)
+	if err := json.Unmarshal(data, dec); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := dec.String(), "/a/img.%04d.exr 1-2 5\n/b/plate_v001.%03d.dpx 101"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if err := json.Unmarshal(data, dec); !errors.Is(err, ErrFrameExists) {
+		t.Fatalf("got err: %v, want: %v", err, ErrFrameExists)
+	}
+}
+
+func TestManagerJSONFrameState(t *testing.T) {
+	toLower := strings.ToLower
+	cases := []struct {
+		opts  []Option
+		files []string
+		name  string
+	}{
+		{
+			opts:  []Option{WithViews(DefaultViews)},
+			files: []string{"/a/img.left.0001.exr", "/a/img.right.0001.exr", "/a/img.left.0002.exr"},
+			name:  "/a/img.%V.####.exr",
+		},
+		{
+			opts:  []Option{WithPadPolicy(PadMerge)},
+			files: []string{"/a/img.99.exr", "/a/img.0100.exr"},
+			name:  "/a/img.##.exr",
+		},
+		{
+			opts:  []Option{WithViews(DefaultViews), WithPadPolicy(PadWidest)},
+			files: []string{"/a/img.left.99.exr", "/a/img.right.0100.exr"},
+			name:  "/a/img.%V.####.exr",
+		},
+		{
+			opts:  []Option{WithNormalizer(toLower)},
+			files: []string{"/a/img.0001.exr", "/a/IMG.0002.EXR"},
+			name:  "/a/img.####.exr",
+		},
+	}
+	for _, c := range cases {
+		man := New(c.opts...)
+		for _, f := range c.files {
+			if err := man.Add(f); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+		}
+		data, err := json.Marshal(man)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		dec := New(c.opts...)
+		if err := json.Unmarshal(data, dec); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		want, _ := man.Files(c.name)
+		got, err := dec.Files(c.name)
+		if err != nil {
+			t.Fatalf("%s: got error: %v", data, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: got: %q, want: %q", data, got, want)
+		}
+		if got, want := dec.PaddingIssues(), man.PaddingIssues(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: got issues: %v, want: %v", data, got, want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package sequence
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidListing = errors.New("invalid listing")
+
+// WriteListing writes the sequences in a canonical listing,
+// which is meant to be stored in git and diffed between publishes.
+//
+// Each line is a sequence with it's quoted pre part, padding,
+// quoted post part and exact frame ranges, like
+//
+//	"/a/img." 4 ".exr" 1-4 98-100
+//
+// The padding of a timecode sequence has it's frame rate, like "8@24".
+//
+// Per-frame state those make file names follow the frames,
+// each after a " | " separator: frames of each stereo view,
+// frames padded unlike the sequence, and frames with their own
+// name parts, like
+//
+//	"/a/img.%V." 4 ".exr" 1-3 | view "left" 1-3 | view "right" 1 3
+//	"/a/img." 2 ".exr" 98-101 | width 4 100-101
+//	"/a/img." 4 ".exr" 1-2 | parts "/a/IMG." ".exr" 2
+//
+// Lines are sorted, so the listing does not depend
+// on the formatter or the order files were added.
+func (m *Manager) WriteListing(w io.Writer) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	lines := make([]string, 0, len(m.Seqs))
+	for _, n := range m.sortedNames() {
+		k := m.keys[n]
+		pad := strconv.Itoa(k.Pad)
+		if k.FPS != 0 {
+			pad += "@" + strconv.Itoa(k.FPS)
+		}
+		line := fmt.Sprintf("%s %s %s %s", strconv.Quote(k.Pre), pad, strconv.Quote(k.Post), m.Seqs[n].exactString())
+		st := m.frameState(n)
+		for _, v := range st.sortedViews() {
+			line += fmt.Sprintf(" | view %s %s", strconv.Quote(v), st.views[v].exactString())
+		}
+		for _, w := range st.sortedWidths() {
+			line += fmt.Sprintf(" | width %d %s", w, st.widths[w].exactString())
+		}
+		for _, p := range st.sortedParts() {
+			line += fmt.Sprintf(" | parts %s %s %s", strconv.Quote(p.pre), strconv.Quote(p.post), st.parts[p].exactString())
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(l)
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ReadListing reads a listing made by WriteListing,
+// and adds it's sequences to the manager.
+// Empty lines are skipped.
+//
+// It returns ErrInvalidListing when a line could not be parsed,
+// then the manager is not changed. Frames those could not be added
+// are returned as joined errors.
+func (m *Manager) ReadListing(r io.Reader) error {
+	type entry struct {
+		k  Key
+		s  *Seq
+		st frameState
+	}
+	entries := []entry{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		k, s, st, err := parseListingLine(line)
+		if err != nil {
+			return fmt.Errorf("%w: line %d: %v", ErrInvalidListing, n, err)
+		}
+		entries = append(entries, entry{k, s, st})
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	errs := []error{}
+	for _, e := range entries {
+		errs = append(errs, m.addKey(e.k, e.s, e.st)...)
+	}
+	return errors.Join(errs...)
+}
+
+// parseListingLine parses a line of a listing.
+func parseListingLine(line string) (Key, *Seq, frameState, error) {
+	st := frameState{
+		views:  make(map[string]*Seq),
+		widths: make(map[int]*Seq),
+		parts:  make(map[nameParts]*Seq),
+	}
+	pre, rest, err := cutQuoted(line)
+	if err != nil {
+		return Key{}, nil, st, err
+	}
+	padStr, rest, _ := strings.Cut(rest, " ")
+	padPart, fpsPart, timecode := strings.Cut(padStr, "@")
+	pad, err := parseFrame(padPart, false)
+	if err != nil {
+		return Key{}, nil, st, fmt.Errorf("invalid padding %q", padStr)
+	}
+	fps := 0
+	if timecode {
+		fps, err = parseFrame(fpsPart, false)
+		if err != nil || fps == 0 {
+			return Key{}, nil, st, fmt.Errorf("invalid padding %q", padStr)
+		}
+	}
+	post, rest, err := cutQuoted(rest)
+	if err != nil {
+		return Key{}, nil, st, err
+	}
+	frames, rest, _ := strings.Cut(rest, " | ")
+	s, err := ParseSeq(frames)
+	if err != nil {
+		return Key{}, nil, st, err
+	}
+	for rest != "" {
+		field, r, _ := strings.Cut(rest, " ")
+		var v, ppre, ppost string
+		w := 0
+		switch field {
+		case "view":
+			v, r, err = cutQuoted(r)
+		case "width":
+			var wStr string
+			wStr, r, _ = strings.Cut(r, " ")
+			w, err = parseFrame(wStr, false)
+			if err == nil && w == 0 {
+				err = fmt.Errorf("invalid width %q", wStr)
+			}
+		case "parts":
+			ppre, r, err = cutQuoted(r)
+			if err == nil {
+				ppost, r, err = cutQuoted(r)
+			}
+		default:
+			err = fmt.Errorf("unknown field %q", field)
+		}
+		if err != nil {
+			return Key{}, nil, st, err
+		}
+		frames, rest, _ = strings.Cut(r, " | ")
+		fs, err := ParseSeq(frames)
+		if err != nil {
+			return Key{}, nil, st, err
+		}
+		switch field {
+		case "view":
+			st.views[v] = fs
+		case "width":
+			st.widths[w] = fs
+		case "parts":
+			st.parts[nameParts{pre: ppre, post: ppost}] = fs
+		}
+	}
+	return Key{Pre: pre, Pad: pad, Post: post, FPS: fps}, s, st, nil
+}
+
+// cutQuoted cuts a quoted string at the start of s,
+// and returns it unquoted and the rest after a following space.
+func cutQuoted(s string) (string, string, error) {
+	q, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid quoted string in %q", s)
+	}
+	u, _ := strconv.Unquote(q)
+	return u, strings.TrimPrefix(s[len(q):], " "), nil
+}
@@ -0,0 +1,139 @@
+package sequence
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestListing(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	files := []string{
+		"/b/plate.101.dpx",
+		"/a/img.0098.exr",
+		"/a/img.0001.exr",
+		"/a/img.0002.exr",
+		"/a/my \"show\".0001.exr",
+		"/a/img.00003.exr",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	var b strings.Builder
+	if err := man.WriteListing(&b); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := `"/a/img." 4 ".exr" 1-2 98` + "\n" +
+		`"/a/img." 5 ".exr" 3` + "\n" +
+		`"/a/my \"show\"." 4 ".exr" 1` + "\n" +
+		`"/b/plate." 3 ".dpx" 101` + "\n"
+	if got := b.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	read := NewManager(DefaultSplitter, FmtPercentD)
+	if err := read.ReadListing(strings.NewReader(want + "\n")); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	var rb strings.Builder
+	if err := read.WriteListing(&rb); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got := rb.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	neg := New(WithSplitter(DefaultSplitter.WithNegative()))
+	for _, f := range []string{"/c/neg.-0002.exr", "/c/neg.-0001.exr", "/c/neg.0000.exr"} {
+		if err := neg.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	var nb strings.Builder
+	neg.WriteListing(&nb)
+	negWant := `"/c/neg." 4 ".exr" -2..0` + "\n"
+	if got := nb.String(); got != negWant {
+		t.Fatalf("got: %q, want: %q", got, negWant)
+	}
+	read = New(WithSplitter(DefaultSplitter.WithNegative()))
+	if err := read.ReadListing(strings.NewReader(negWant)); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if s, _ := read.Seq("/c/neg.####.exr"); s.String() != "-2..0" {
+		t.Fatalf("got: %q, want: %q", s, "-2..0")
+	}
+
+	for _, bad := range []string{`/a/img. 4 ".exr" 1`, `"/a/img." x ".exr" 1`, `"/a/img." 4 ".exr" 2-1`} {
+		empty := NewManager(DefaultSplitter, FmtSharp)
+		if err := empty.ReadListing(strings.NewReader(want + bad)); !errors.Is(err, ErrInvalidListing) {
+			t.Fatalf("%q: got err: %v, want: %v", bad, err, ErrInvalidListing)
+		}
+		if len(empty.SeqNames()) != 0 {
+			t.Fatalf("%q: manager changed by invalid listing", bad)
+		}
+	}
+}
+
+func TestListingFrameState(t *testing.T) {
+	man := New(WithViews(DefaultViews), WithPadPolicy(PadMerge), WithNormalizer(strings.ToLower))
+	files := []string{
+		"/a/img.left.0001.exr",
+		"/a/img.right.0001.exr",
+		"/a/img.left.0002.exr",
+		"/b/img.99.exr",
+		"/b/img.0100.exr",
+		"/c/img.0001.exr",
+		"/c/IMG.0002.exr",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	var b strings.Builder
+	if err := man.WriteListing(&b); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := `"/a/img.%V." 4 ".exr" 1-2 | view "left" 1-2 | view "right" 1` + "\n" +
+		`"/b/img." 2 ".exr" 99-100 | width 4 100` + "\n" +
+		`"/c/img." 4 ".exr" 1-2 | parts "/c/IMG." ".exr" 2` + "\n"
+	if got := b.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	for _, read := range []*Manager{New(), New(WithViews(DefaultViews), WithPadPolicy(PadMerge), WithNormalizer(strings.ToLower))} {
+		if err := read.ReadListing(strings.NewReader(want)); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		var rb strings.Builder
+		read.WriteListing(&rb)
+		if got := rb.String(); got != want {
+			t.Fatalf("got: %q, want: %q", got, want)
+		}
+		// Names could differ by the normalizer, but not the files.
+		if got, want := allFiles(read), allFiles(man); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got: %q, want: %q", got, want)
+		}
+	}
+
+	for _, bad := range []string{`"/a/img." 4 ".exr" 1 | size 4 1`, `"/a/img." 4 ".exr" 1 | width x 1`, `"/a/img." 4 ".exr" 1 | view left 1`, `"/a/img." 4 ".exr" 1 | parts "/a/IMG." 1`} {
+		if err := New().ReadListing(strings.NewReader(bad)); !errors.Is(err, ErrInvalidListing) {
+			t.Fatalf("%q: got err: %v, want: %v", bad, err, ErrInvalidListing)
+		}
+	}
+}
+
+// allFiles returns files of all sequences of a manager, in order.
+func allFiles(m *Manager) []string {
+	files := []string{}
+	for _, n := range m.SeqNames() {
+		fs, _ := m.Files(n)
+		files = append(files, fs...)
+	}
+	sort.Strings(files)
+	return files
+}
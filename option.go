@@ -0,0 +1,65 @@
+package sequence
+
+// An Option configures a manager created by New.
+type Option func(*Manager)
+
+// New creates a new sequence manager with options.
+// Without options, it is same as NewManager(DefaultSplitter, FmtSharp).
+func New(opts ...Option) *Manager {
+	m := NewManager(DefaultSplitter, FmtSharp)
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// WithSplitter sets the splitter of the manager.
+func WithSplitter(s *Splitter) Option {
+	return func(m *Manager) {
+		m.splitter = s
+	}
+}
+
+// WithFormatter sets the formatter of the manager.
+func WithFormatter(f Formatter) Option {
+	return func(m *Manager) {
+		m.formatter = f
+	}
+}
+
+// WithNormalizer sets the normalizer of the manager. See SetNormalizer.
+func WithNormalizer(normalize func(string) string) Option {
+	return func(m *Manager) {
+		m.SetNormalizer(normalize)
+	}
+}
+
+// WithPadPolicy sets the pad policy of the manager. See SetPadPolicy.
+func WithPadPolicy(p PadPolicy) Option {
+	return func(m *Manager) {
+		m.SetPadPolicy(p)
+	}
+}
+
+// WithViews sets the stereo views of the manager. See SetViews.
+func WithViews(views []string) Option {
+	return func(m *Manager) {
+		m.SetViews(views)
+	}
+}
+
+// WithLess sets the order of sequence names of the manager. See SetLess.
+func WithLess(less func(a, b string) bool) Option {
+	return func(m *Manager) {
+		m.SetLess(less)
+	}
+}
+
+// FormatFunc makes a formatter from a format function,
+// which was the formatter type before Formatter.
+// It's Parse is ParseName.
+//
+// Deprecated: Use NewFormatter, which also takes a parse function.
+func FormatFunc(format func(pre, digits, post string) string) Formatter {
+	return NewFormatter(format, ParseName)
+}
@@ -0,0 +1,68 @@
+package sequence
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// The example code should keep compiling and printing the same output.
+func TestExampleCompat(t *testing.T) {
+	managers := map[string]*Manager{
+		"NewManager": NewManager(DefaultSplitter, FmtSharp),
+		"New":        New(),
+		"New options": New(
+			WithSplitter(DefaultSplitter),
+			WithFormatter(FmtSharp),
+			WithPadPolicy(PadStrict),
+		),
+		"FormatFunc": NewManager(DefaultSplitter, FormatFunc(func(pre, digits, post string) string {
+			return pre + strings.Repeat("#", len(digits)) + post
+		})),
+	}
+	for n, man := range managers {
+		if err := man.Scan(os.DirFS("example/data"), "."); err != nil {
+			t.Fatalf("%s: got error: %v", n, err)
+		}
+
+		// example/basic.go
+		if got, want := fmt.Sprint(man), "another.####.exr 1-4 7-10\nimg.####.exr 1-3"; got != want {
+			t.Fatalf("%s: got: %q, want: %q", n, got, want)
+		}
+
+		// example/custom.go
+		var b strings.Builder
+		for _, sn := range man.SeqNames() {
+			seq := man.Seqs[sn]
+			for _, r := range seq.Ranges() {
+				fmt.Fprintln(&b, sn, r)
+			}
+		}
+		if got, want := b.String(), "another.####.exr 1-4\nanother.####.exr 7-10\nimg.####.exr 1-3\n"; got != want {
+			t.Fatalf("%s: got: %q, want: %q", n, got, want)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	man := New(
+		WithSplitter(DefaultSplitter.WithNegative()),
+		WithFormatter(FmtPercentD),
+		WithPadPolicy(PadWidest),
+		WithViews(DefaultViews),
+	)
+	for _, f := range []string{"/a/img.left.-001.exr", "/a/img.right.001.exr", "/a/img.left.001.exr", "/b/img.99.exr", "/b/img.0100.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if got, want := man.String(), "/a/img.%V.%03d.exr -1 1\n/b/img.%04d.exr 99-100"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	k, err := FormatFunc(FmtDollarF.Format).Parse("/a/img.$F4.exr")
+	if err != nil || k != (Key{Pre: "/a/img.", Pad: 4, Post: ".exr"}) {
+		t.Fatalf("got: %v, %v", k, err)
+	}
+}
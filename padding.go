@@ -0,0 +1,142 @@
+package sequence
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PadPolicy decides how a manager groups files
+// those differ only in their padding, like "img.99.exr" and "img.0100.exr".
+type PadPolicy int
+
+const (
+	// PadStrict puts files with different padding in different sequences.
+	// It is the default policy.
+	PadStrict PadPolicy = iota
+
+	// PadMerge puts them in one sequence,
+	// which keeps the padding of the first added file.
+	PadMerge
+
+	// PadWidest puts them in one sequence,
+	// which takes the widest padding of the added files.
+	PadWidest
+)
+
+// SetPadPolicy sets how the manager groups files with different padding.
+// Use PaddingIssues to find the inconsistent frames of merged sequences.
+//
+// It should be set before adding files.
+func (m *Manager) SetPadPolicy(p PadPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.padPolicy = p
+	m.groups = make(map[string]string)
+	m.widths = make(map[string]map[int]int)
+}
+
+// PaddingIssues returns frames whose file names are not padded
+// like their sequence's padding, by sequence names.
+// It only finds them in sequences merged by the pad policy.
+func (m *Manager) PaddingIssues() map[string][]int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	issues := make(map[string][]int)
+	for n, ws := range m.widths {
+		pad := m.keys[n].Pad
+		for f, w := range ws {
+			a := f
+			if a < 0 {
+				a = -a
+			}
+			want := len(strconv.Itoa(a))
+			if want < pad {
+				want = pad
+			}
+			if w != want {
+				issues[n] = append(issues[n], f)
+			}
+		}
+		sort.Ints(issues[n])
+	}
+	return issues
+}
+
+// ident returns an identity of a file's sequence used while validating
+// staged operations, which does not change when merged sequences are re-padded.
+func (m *Manager) ident(name string, k Key) string {
+	if m.padPolicy == PadStrict {
+		return name
+	}
+	return m.groupID(k)
+}
+
+// groupID returns the id of a key's (pre, post) group.
+func (m *Manager) groupID(k Key) string {
+	return m.norm(k.Pre) + "\x00" + m.norm(k.Post)
+}
+
+// addMerged adds a frame to the sequence of it's (pre, post) group,
+// in the view if it is not empty.
+// The sequence will be re-padded when the pad policy is PadWidest.
+// The caller should hold the lock.
+func (m *Manager) addMerged(name string, k Key, frame int, view string) error {
+	gid := m.groupID(k)
+	width := k.Pad
+	isNew := true
+	if cur, ok := m.groups[gid]; ok {
+		if m.hasFile(cur, frame, view) {
+			return ErrFrameExists
+		}
+		isNew = !m.Seqs[cur].frames.has(frame)
+		if m.padPolicy == PadWidest && width > m.keys[cur].Pad {
+			cur = m.repad(cur, width)
+		}
+		name = cur
+	}
+	var err error
+	if view != "" {
+		err = m.addView(name, k, frame, view)
+	} else {
+		err = m.add(name, k, frame)
+	}
+	if err != nil {
+		return err
+	}
+	m.groups[gid] = name
+	// Views of a frame share it's width, which is of the first added view.
+	if isNew {
+		if m.widths[name] == nil {
+			m.widths[name] = make(map[int]int)
+		}
+		m.widths[name][frame] = width
+	}
+	return nil
+}
+
+// repad changes the padding of a sequence, and renames it.
+// It returns the new name. The caller should hold the lock.
+func (m *Manager) repad(name string, pad int) string {
+	k := m.keys[name]
+	k.Pad = pad
+	n := m.formatter.Format(m.norm(k.Pre), strings.Repeat("0", pad), m.norm(k.Post))
+	if n == name {
+		m.keys[name] = k
+		return name
+	}
+	m.Seqs[n] = m.Seqs[name]
+	m.keys[n] = k
+	m.widths[n] = m.widths[name]
+	m.groups[m.groupID(k)] = n
+	if ps, ok := m.parts[name]; ok {
+		m.parts[n] = ps
+		delete(m.parts, name)
+	}
+	delete(m.Seqs, name)
+	delete(m.keys, name)
+	delete(m.widths, name)
+	m.removeName()
+	m.addName(n)
+	return n
+}
@@ -0,0 +1,99 @@
+package sequence
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPadPolicy(t *testing.T) {
+	files := []string{
+		"/a/img.98.exr",
+		"/a/img.99.exr",
+		"/a/img.0100.exr",
+		"/a/img.0101.exr",
+		"/a/img.00102.exr",
+	}
+	cases := []struct {
+		policy     PadPolicy
+		want       string
+		wantIssues map[string][]int
+	}{
+		{
+			policy:     PadStrict,
+			want:       "/a/img.#####.exr 102\n/a/img.####.exr 100-101\n/a/img.##.exr 98-99",
+			wantIssues: map[string][]int{},
+		},
+		{
+			policy: PadMerge,
+			want:   "/a/img.##.exr 98-102",
+			wantIssues: map[string][]int{
+				"/a/img.##.exr": {100, 101, 102},
+			},
+		},
+		{
+			policy: PadWidest,
+			want:   "/a/img.#####.exr 98-102",
+			wantIssues: map[string][]int{
+				"/a/img.#####.exr": {98, 99, 100, 101},
+			},
+		},
+	}
+	for _, c := range cases {
+		man := NewManager(DefaultSplitter, FmtSharp)
+		man.SetPadPolicy(c.policy)
+		for _, f := range files {
+			if err := man.Add(f); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+		}
+		if got := man.String(); got != c.want {
+			t.Fatalf("policy %d - got: %q, want: %q", c.policy, got, c.want)
+		}
+		if got := man.PaddingIssues(); !reflect.DeepEqual(got, c.wantIssues) {
+			t.Fatalf("policy %d - got issues: %v, want: %v", c.policy, got, c.wantIssues)
+		}
+	}
+}
+
+func TestPadPolicyMutations(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	man.SetPadPolicy(PadWidest)
+	tx := man.Begin()
+	tx.Add("/a/img.9.exr")
+	tx.Add("/a/img.0010.exr")
+	tx.Add("/a/img.09.exr")
+	if err := tx.Commit(); err == nil {
+		t.Fatalf("frame 9 added twice should fail")
+	}
+	if got := man.SeqNames(); len(got) != 0 {
+		t.Fatalf("manager changed by failed commit: %q", got)
+	}
+
+	for _, f := range []string{"/a/img.9.exr", "/a/img.0010.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if err := man.Add("/a/img.09.exr"); err == nil {
+		t.Fatalf("frame 9 added twice should fail")
+	}
+	if err := man.Remove("/a/img.9.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got := man.PaddingIssues(); len(got) != 0 {
+		t.Fatalf("got issues: %v, want none", got)
+	}
+	if err := man.Rekey(FmtPercentD); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if err := man.Add("/a/img.11.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := map[string][]int{"/a/img.%04d.exr": {11}}
+	if got := man.PaddingIssues(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got issues: %v, want: %v", got, want)
+	}
+	if got, want := man.String(), "/a/img.%04d.exr 10-11"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
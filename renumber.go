@@ -0,0 +1,242 @@
+package sequence
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// A Rename is a file rename of a planned operation.
+type Rename struct {
+	From string
+	To   string
+}
+
+// RenumberPlan returns the file renames to renumber a sequence
+// to a new start frame and step, in frame order. See Renumber.
+// Sources and targets could overlap, so the renames should not be
+// applied one by one in place. See fsops.Renumber.
+func (m *Manager) RenumberPlan(name string, start, step int) ([]Rename, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	mapping, err := m.renumberMapping(name, start, step, 1)
+	if err != nil {
+		return nil, err
+	}
+	return m.renamePlan(name, nil, mapping, false), nil
+}
+
+// RespacePlan returns the file renames to respace a sequence. See Respace.
+func (m *Manager) RespacePlan(name string, start, stride, step int) ([]Rename, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	mapping, err := m.renumberMapping(name, start, step, stride)
+	if err != nil {
+		return nil, err
+	}
+	return m.renamePlan(name, nil, mapping, false), nil
+}
+
+// RenamePlan returns the file renames to move a sequence to the dst key,
+// like "/b/shot.####.exr" from "/a/img.####.exr", in frame order.
+// When step is not 0, the frames are also renumbered
+// to start and step, like Renumber does.
+//
+// Excluded frames are moved with the sequence, so they are not left behind.
+// Use CopyPlan to copy a sequence without them.
+func (m *Manager) RenamePlan(name string, dst Key, start, step int) ([]Rename, error) {
+	return m.movePlan(name, dst, start, step, false)
+}
+
+// CopyPlan returns the file copies to copy a sequence to the dst key,
+// like RenamePlan, but excluded frames are skipped. See fsops.Copy.
+func (m *Manager) CopyPlan(name string, dst Key, start, step int) ([]Rename, error) {
+	return m.movePlan(name, dst, start, step, true)
+}
+
+// movePlan returns the file renames of RenamePlan,
+// without excluded frames when skipExcluded is true.
+func (m *Manager) movePlan(name string, dst Key, start, step int, skipExcluded bool) ([]Rename, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.Seqs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSeqNotFound, name)
+	}
+	mapping := make(map[int]int)
+	if step == 0 {
+		for f := range s.frames.all() {
+			mapping[f] = f
+		}
+	} else {
+		var err error
+		mapping, err = m.renumberMapping(name, start, step, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m.renamePlan(name, &dst, mapping, skipExcluded), nil
+}
+
+// renamePlan returns the file renames of a sequence's frames
+// to the dst key and the mapped frames. A nil dst renames the files in place,
+// so each frame keeps it's own parts. Excluded frames are skipped
+// when skipExcluded is true. The caller should hold the lock.
+func (m *Manager) renamePlan(name string, dst *Key, mapping map[int]int, skipExcluded bool) []Rename {
+	s := m.Seqs[name]
+	renames := []Rename{}
+	for f := range s.frames.all() {
+		if skipExcluded && s.Excluded(f) {
+			continue
+		}
+		views := []string{""}
+		if s.views != nil {
+			views = s.Views(f)
+		}
+		sk := m.frameKey(name, f)
+		dk := m.keys[name]
+		if dst != nil {
+			dk = *dst
+		} else {
+			dk.Pre, dk.Post = sk.Pre, sk.Post
+		}
+		for _, v := range views {
+			renames = append(renames, Rename{From: viewFileName(sk, v, f), To: viewFileName(dk, v, mapping[f])})
+		}
+	}
+	return renames
+}
+
+// Renumber renumbers a sequence to a new start frame and step,
+// like shifting 1001-1100 to 1-100. It does not rename files on disk,
+// use fsops.Renumber for that.
+//
+// Frames keep their offsets from the first frame, multiplied by step,
+// so "1001-1003 1005" with step 1 becomes "1-3 5", and gaps are kept.
+// Per-frame data of the sequence, like statuses, are moved with the frames.
+func (m *Manager) Renumber(name string, start, step int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mapping, err := m.renumberMapping(name, start, step, 1)
+	if err != nil {
+		return err
+	}
+	m.remapFrames(name, mapping)
+	return nil
+}
+
+// Respace renumbers a sequence rendered on the old stride, like on twos,
+// to a new start frame and step. The offsets from the first frame are
+// divided by stride, so "1001-1099x2" with stride 2 and step 1 becomes "1-50".
+// It returns ErrInvalidRange when a frame is not on the stride.
+// Use fsops.Respace to rename the files on disk.
+func (m *Manager) Respace(name string, start, stride, step int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mapping, err := m.renumberMapping(name, start, step, stride)
+	if err != nil {
+		return err
+	}
+	m.remapFrames(name, mapping)
+	return nil
+}
+
+// remapFrames renumbers a sequence's frames, widths and parts by mapping.
+// The caller should hold the lock.
+func (m *Manager) remapFrames(name string, mapping map[int]int) {
+	m.Seqs[name] = m.Seqs[name].remap(mapping)
+	if ps, ok := m.parts[name]; ok {
+		m.parts[name] = make(map[int]nameParts, len(ps))
+		for f, p := range ps {
+			m.parts[name][mapping[f]] = p
+		}
+	}
+	if ws, ok := m.widths[name]; ok {
+		pad := m.keys[name].Pad
+		m.widths[name] = make(map[int]int, len(ws))
+		for _, nf := range mapping {
+			a := nf
+			if a < 0 {
+				a = -a
+			}
+			m.widths[name][nf] = max(pad, len(strconv.Itoa(a)))
+		}
+	}
+}
+
+// renumberMapping returns new frames of a sequence's frames.
+// Offsets from the first frame are divided by stride, then multiplied by step.
+// The caller should hold the lock.
+func (m *Manager) renumberMapping(name string, start, step, stride int) (map[int]int, error) {
+	s, ok := m.Seqs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSeqNotFound, name)
+	}
+	if step < 1 {
+		return nil, fmt.Errorf("%w: step %d", ErrInvalidRange, step)
+	}
+	if stride < 1 {
+		return nil, fmt.Errorf("%w: stride %d", ErrInvalidRange, stride)
+	}
+	first, _, _ := s.bounds()
+	mapping := make(map[int]int, s.Len())
+	for f := range s.frames.all() {
+		if (f-first)%stride != 0 {
+			return nil, fmt.Errorf("%w: frame %d is not on stride %d", ErrInvalidRange, f, stride)
+		}
+		nf := start + (f-first)/stride*step
+		if nf < 0 && !s.allowNegative {
+			return nil, ErrNegativeFrame
+		}
+		mapping[f] = nf
+	}
+	return mapping, nil
+}
+
+// viewFileName returns the file name of a frame in a view,
+// or of a frame if the view is empty.
+func viewFileName(k Key, view string, f int) string {
+	if view == "" {
+		return k.FileName(f)
+	}
+	return k.ViewFileName(view, f)
+}
+
+// remap returns a copy of the sequence with it's frames renumbered by mapping.
+// Per-frame data are moved with the frames,
+// and dropped for the frames those are not in mapping.
+func (s *Seq) remap(mapping map[int]int) *Seq {
+	c := NewSeq()
+	c.gapTolerance = s.gapTolerance
+	c.allowNegative = s.allowNegative
+	for f, nf := range mapping {
+		if !s.frames.has(f) {
+			continue
+		}
+		c.frames.add(nf)
+		if a, ok := s.attempts[f]; ok {
+			if c.attempts == nil {
+				c.attempts = make(map[int]int)
+			}
+			c.attempts[nf] = a
+		}
+		if s.Excluded(f) {
+			if c.excluded == nil {
+				c.excluded = make(map[int]struct{})
+			}
+			c.excluded[nf] = struct{}{}
+		}
+		if st, ok := s.statuses[f]; ok {
+			c.SetStatus(nf, st)
+		}
+		if vs, ok := s.views[f]; ok {
+			if c.views == nil {
+				c.views = make(map[int]map[string]struct{})
+			}
+			c.views[nf] = make(map[string]struct{}, len(vs))
+			for v := range vs {
+				c.views[nf][v] = struct{}{}
+			}
+		}
+	}
+	return c
+}
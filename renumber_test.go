@@ -0,0 +1,153 @@
+package sequence
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRenumberPlan(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"/a/img.1001.exr", "/a/img.1003.exr", "/a/img.1007.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	got, err := man.RenumberPlan("/a/img.####.exr", 1, 1)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := []Rename{
+		{From: "/a/img.1001.exr", To: "/a/img.0001.exr"},
+		{From: "/a/img.1003.exr", To: "/a/img.0003.exr"},
+		{From: "/a/img.1007.exr", To: "/a/img.0007.exr"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+	if s, _ := man.Seq("/a/img.####.exr"); s.String() != "1001 1003 1007" {
+		t.Fatalf("manager changed by plan: %q", s)
+	}
+	if _, err := man.RenumberPlan("/a/img.####.exr", 1, 0); !errors.Is(err, ErrInvalidRange) {
+		t.Fatalf("got err: %v, want: %v", err, ErrInvalidRange)
+	}
+
+	got, err = man.RespacePlan("/a/img.####.exr", 1, 2, 1)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want = []Rename{
+		{From: "/a/img.1001.exr", To: "/a/img.0001.exr"},
+		{From: "/a/img.1003.exr", To: "/a/img.0002.exr"},
+		{From: "/a/img.1007.exr", To: "/a/img.0004.exr"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+	if _, err := man.RespacePlan("/a/img.####.exr", 1, 4, 1); !errors.Is(err, ErrInvalidRange) {
+		t.Fatalf("got err: %v, want: %v", err, ErrInvalidRange)
+	}
+}
+
+func TestRenumber(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"/a/img.1001.exr", "/a/img.1002.exr", "/a/img.1004.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	man.Seqs["/a/img.####.exr"].SetStatus(1004, StatusDone)
+	if err := man.Renumber("/a/img.####.exr", 1, 1); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	s, _ := man.Seq("/a/img.####.exr")
+	if got, want := s.String(), "1-2 4"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if st, _ := s.Status(4); st != StatusDone {
+		t.Fatalf("got status: %q, want: %q", st, StatusDone)
+	}
+	if err := man.Renumber("/a/img.####.exr", -10, 1); !errors.Is(err, ErrNegativeFrame) {
+		t.Fatalf("got err: %v, want: %v", err, ErrNegativeFrame)
+	}
+
+	for _, f := range []string{"/b/img.1001.exr", "/b/img.1003.exr", "/b/img.1007.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if err := man.Renumber("/b/img.####.exr", 1, 1); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	s, _ = man.Seq("/b/img.####.exr")
+	if got, want := s.String(), "1 3 7"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if err := man.Respace("/b/img.####.exr", 1, 2, 1); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	s, _ = man.Seq("/b/img.####.exr")
+	if got, want := s.String(), "1-2 4"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestRenamePlan(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"/a/img.1001.exr", "/a/img.1002.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	dst := Key{Pre: "/b/shot_", Pad: 3, Post: ".exr"}
+	cases := []struct {
+		start, step int
+		want        []Rename
+	}{
+		{
+			want: []Rename{
+				{From: "/a/img.1001.exr", To: "/b/shot_1001.exr"},
+				{From: "/a/img.1002.exr", To: "/b/shot_1002.exr"},
+			},
+		},
+		{
+			start: 1,
+			step:  2,
+			want: []Rename{
+				{From: "/a/img.1001.exr", To: "/b/shot_001.exr"},
+				{From: "/a/img.1002.exr", To: "/b/shot_003.exr"},
+			},
+		},
+	}
+	for _, c := range cases {
+		got, err := man.RenamePlan("/a/img.####.exr", dst, c.start, c.step)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("got: %v, want: %v", got, c.want)
+		}
+	}
+	if _, err := man.RenamePlan("/a/none.####.exr", dst, 0, 0); !errors.Is(err, ErrSeqNotFound) {
+		t.Fatalf("got err: %v, want: %v", err, ErrSeqNotFound)
+	}
+
+	// Excluded frames are moved, but not copied.
+	bad := NewSeq()
+	bad.AddFrame(1002)
+	man.Seqs["/a/img.####.exr"].Exclude(bad)
+	got, err := man.RenamePlan("/a/img.####.exr", dst, 0, 0)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if !reflect.DeepEqual(got, cases[0].want) {
+		t.Fatalf("got: %v, want: %v", got, cases[0].want)
+	}
+	got, err = man.CopyPlan("/a/img.####.exr", dst, 0, 0)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if want := cases[0].want[:1]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+}
@@ -0,0 +1,145 @@
+package sequence
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// reportStripWidth is the width of presence strips in reports.
+const reportStripWidth = 60
+
+// reportRow is a sequence row of a report.
+type reportRow struct {
+	Name    string
+	Frames  string
+	Count   int
+	First   int
+	Last    int
+	Missing int
+	Gaps    string
+	Strip   string
+}
+
+// reportRows returns report rows of all sequences in name order.
+// The caller should hold the lock.
+func (m *Manager) reportRows() []reportRow {
+	rows := []reportRow{}
+	for _, n := range m.sortedNames() {
+		s := m.Seqs[n]
+		min, max, ok := s.bounds()
+		if !ok {
+			continue
+		}
+		gaps := []string{}
+		missing := 0
+		for _, g := range s.Gaps() {
+			gaps = append(gaps, g.String())
+			missing += g.Max - g.Min + 1
+		}
+		rows = append(rows, reportRow{
+			Name:    n,
+			Frames:  s.String(),
+			Count:   s.Len(),
+			First:   min,
+			Last:    max,
+			Missing: missing,
+			Gaps:    strings.Join(gaps, " "),
+			Strip:   s.Strip(Range{Min: min, Max: max}, reportStripWidth),
+		})
+	}
+	return rows
+}
+
+var htmlReport = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Sequence Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { cursor: pointer; background: #eee; }
+td.num { text-align: right; }
+.strip { font-family: monospace; white-space: pre; }
+.missing { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Sequence Report</h1>
+<p>{{len .}} sequence(s)</p>
+<table id="seqs">
+<thead>
+<tr><th>Sequence</th><th>Frames</th><th>Count</th><th>First</th><th>Last</th><th>Missing</th><th>Presence</th></tr>
+</thead>
+<tbody>
+{{- range .}}
+<tr>
+<td>{{.Name}}</td>
+<td>{{.Frames}}</td>
+<td class="num">{{.Count}}</td>
+<td class="num">{{.First}}</td>
+<td class="num">{{.Last}}</td>
+<td class="num">{{if .Missing}}<details><summary class="missing">{{.Missing}}</summary>{{.Gaps}}</details>{{else}}0{{end}}</td>
+<td class="strip">{{.Strip}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+<script>
+document.querySelectorAll("#seqs th").forEach(function(th, col) {
+	var asc = true;
+	th.addEventListener("click", function() {
+		var tbody = document.querySelector("#seqs tbody");
+		var rows = Array.from(tbody.rows);
+		rows.sort(function(a, b) {
+			var x = a.cells[col].innerText, y = b.cells[col].innerText;
+			var d = (col >= 2 && col <= 5) ? parseInt(x) - parseInt(y) : x.localeCompare(y);
+			return asc ? d : -d;
+		});
+		asc = !asc;
+		rows.forEach(function(r) { tbody.appendChild(r); });
+	});
+});
+</script>
+</body>
+</html>
+`))
+
+// WriteHTMLReport writes a standalone HTML page of the sequences,
+// which has a sortable table with presence strips and missing frames.
+// It does not need any other file to be opened in a browser.
+func (m *Manager) WriteHTMLReport(w io.Writer) error {
+	m.mu.RLock()
+	rows := m.reportRows()
+	m.mu.RUnlock()
+	return htmlReport.Execute(w, rows)
+}
+
+// WriteMarkdown writes a markdown table of the sequences
+// with their ranges and missing frames, for tickets and chats.
+func (m *Manager) WriteMarkdown(w io.Writer) error {
+	m.mu.RLock()
+	rows := m.reportRows()
+	m.mu.RUnlock()
+
+	var b strings.Builder
+	b.WriteString("| Sequence | Frames | Count | Missing |\n")
+	b.WriteString("| --- | --- | ---: | --- |\n")
+	for _, r := range rows {
+		missing := "-"
+		if r.Missing != 0 {
+			missing = fmt.Sprintf("%d (%s)", r.Missing, r.Gaps)
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %d | %s |\n", markdownEscape(r.Name), r.Frames, r.Count, missing)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscape escapes characters those break a markdown table cell.
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "`", "'", "\n", " ").Replace(s)
+}
@@ -0,0 +1,64 @@
+package sequence
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteHTMLReport(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	files := []string{
+		"/a/img.0001.exr",
+		"/a/img.0002.exr",
+		"/a/img.0005.exr",
+		"/a/<b>.0001.exr",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	var b strings.Builder
+	if err := man.WriteHTMLReport(&b); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"<td>/a/img.####.exr</td>",
+		"<td>1-2 5</td>",
+		`<summary class="missing">2</summary>3-4</details>`,
+		`<td class="strip">██░░█</td>`,
+		"<td>/a/&lt;b&gt;.####.exr</td>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("report does not have %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	files := []string{
+		"/a/img.0001.exr",
+		"/a/img.0002.exr",
+		"/a/img.0005.exr",
+		"/a/img.0008.exr",
+		"/b/a|b.0001.exr",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	var b strings.Builder
+	if err := man.WriteMarkdown(&b); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := "| Sequence | Frames | Count | Missing |\n" +
+		"| --- | --- | ---: | --- |\n" +
+		"| `/a/img.####.exr` | 1-2 5 8 | 4 | 4 (3-4 6-7) |\n" +
+		"| `/b/a\\|b.####.exr` | 1 | 1 | - |\n"
+	if got := b.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+package sequence
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// DefaultResolutionDirs are directory names of resolution variants,
+// from the reference resolution to lower ones.
+var DefaultResolutionDirs = []string{"full", "half", "quarter", "proxy"}
+
+// A ResolutionGroup is a group of sequences those are
+// resolution variants of a same sequence, in sibling directories.
+type ResolutionGroup struct {
+	// Names holds sequence names by their resolution directory names.
+	Names map[string]string
+
+	// Missing holds frames each variant misses,
+	// compared to the reference resolution variant.
+	// It is empty when the group does not have the reference resolution.
+	Missing map[string][]int
+}
+
+// ResolutionGroups pairs sequences in resolution directories like
+// "plate/full/img.####.exr" and "plate/half/img.####.jpg".
+// Their directory names should be one of dirs, and the first one
+// is the reference resolution. Nil dirs means DefaultResolutionDirs.
+//
+// Variants are paired by their parent directory, base name and padding,
+// so they could have different extensions.
+// The groups are sorted by their variants' common path.
+func (m *Manager) ResolutionGroups(dirs []string) []*ResolutionGroup {
+	if dirs == nil {
+		dirs = DefaultResolutionDirs
+	}
+	isResDir := make(map[string]bool)
+	for _, d := range dirs {
+		isResDir[d] = true
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	groups := make(map[string]*ResolutionGroup)
+	for _, n := range m.sortedNames() {
+		k := m.keys[n]
+		dir, base := filepath.Split(k.Pre + "_")
+		dir = filepath.Clean(dir)
+		res := filepath.Base(dir)
+		if !isResDir[res] {
+			continue
+		}
+		id := filepath.Join(filepath.Dir(dir), base[:len(base)-1]) + "\x00" + strconv.Itoa(k.Pad)
+		g, ok := groups[id]
+		if !ok {
+			g = &ResolutionGroup{Names: make(map[string]string), Missing: make(map[string][]int)}
+			groups[id] = g
+		}
+		g.Names[res] = n
+	}
+
+	ids := []string{}
+	for id, g := range groups {
+		ids = append(ids, id)
+		ref, ok := g.Names[dirs[0]]
+		if !ok {
+			continue
+		}
+		refSeq := m.Seqs[ref]
+		for res, n := range g.Names {
+			if res == dirs[0] {
+				continue
+			}
+			g.Missing[res] = refSeq.Subtract(m.Seqs[n]).sortedFrames()
+		}
+	}
+	sort.Strings(ids)
+	gs := []*ResolutionGroup{}
+	for _, id := range ids {
+		gs = append(gs, groups[id])
+	}
+	return gs
+}
+
+// ProxyPlan returns frames of a full resolution sequence
+// those are not in the proxy sequence yet, in ascending order,
+// with file names of the proxies to be generated.
+// Excluded frames of the full resolution sequence are skipped.
+//
+// The proxy key could be got by Key, or made for a new proxy sequence.
+// It returns ErrSeqNotFound when the manager does not have the full sequence.
+func (m *Manager) ProxyPlan(full string, proxy Key) ([]int, []string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	fs, ok := m.Seqs[full]
+	if !ok {
+		return nil, nil, ErrSeqNotFound
+	}
+	ps := NewSeq()
+	for n, k := range m.keys {
+		if k == proxy {
+			ps = m.Seqs[n]
+			break
+		}
+	}
+	frames := []int{}
+	files := []string{}
+	for _, f := range fs.sortedFrames() {
+		if ps.frames.has(f) || fs.Excluded(f) {
+			continue
+		}
+		frames = append(frames, f)
+		files = append(files, proxy.FileName(f))
+	}
+	return frames, files, nil
+}
@@ -0,0 +1,92 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestResolutionGroups(t *testing.T) {
+	files := map[string]string{
+		"/sh010/plate/full/img.%04d.exr":  "1-10",
+		"/sh010/plate/half/img.%04d.exr":  "1-10",
+		"/sh010/plate/proxy/img.%04d.jpg": "1-5 8-10",
+		"/sh020/plate/half/img.%04d.exr":  "1-3",
+		"/sh020/plate/other/img.%04d.exr": "1-3",
+	}
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for pat, rng := range files {
+		s, err := ParseSeq(rng)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		for _, f := range s.sortedFrames() {
+			if err := man.Add(fmt.Sprintf(pat, f)); err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+		}
+	}
+
+	got := man.ResolutionGroups(nil)
+	want := []*ResolutionGroup{
+		{
+			Names: map[string]string{
+				"full":  "/sh010/plate/full/img.####.exr",
+				"half":  "/sh010/plate/half/img.####.exr",
+				"proxy": "/sh010/plate/proxy/img.####.jpg",
+			},
+			Missing: map[string][]int{
+				"half":  {},
+				"proxy": {6, 7},
+			},
+		},
+		{
+			Names: map[string]string{
+				"half": "/sh020/plate/half/img.####.exr",
+			},
+			Missing: map[string][]int{},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+}
+
+func TestProxyPlan(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{
+		"/p/full/img.0001.exr", "/p/full/img.0002.exr", "/p/full/img.0003.exr", "/p/full/img.0004.exr",
+		"/p/proxy/img.0002.jpg",
+	} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	bad, _ := ParseSeq("4")
+	man.Seqs["/p/full/img.####.exr"].Exclude(bad)
+
+	proxy, _ := man.Key("/p/proxy/img.####.jpg")
+	frames, files, err := man.ProxyPlan("/p/full/img.####.exr", proxy)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if want := []int{1, 3}; !reflect.DeepEqual(frames, want) {
+		t.Fatalf("got frames: %v, want: %v", frames, want)
+	}
+	if want := []string{"/p/proxy/img.0001.jpg", "/p/proxy/img.0003.jpg"}; !reflect.DeepEqual(files, want) {
+		t.Fatalf("got files: %q, want: %q", files, want)
+	}
+
+	newProxy := Key{Pre: "/p/quarter/img.", Pad: 4, Post: ".jpg"}
+	frames, _, err = man.ProxyPlan("/p/full/img.####.exr", newProxy)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(frames, want) {
+		t.Fatalf("got frames: %v, want: %v", frames, want)
+	}
+	if _, _, err := man.ProxyPlan("/p/none.####.exr", proxy); !errors.Is(err, ErrSeqNotFound) {
+		t.Fatalf("got err: %v, want: %v", err, ErrSeqNotFound)
+	}
+}
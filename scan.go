@@ -0,0 +1,35 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Scan walks the file tree rooted at root in fsys, and adds every file.
+// Use os.DirFS to scan a directory on disk.
+//
+// File names are the paths in fsys, like "root/sub/img.0001.exr".
+// Non-sequence files are skipped. Other errors from adding files
+// are joined and returned after walking the whole tree,
+// while an error from walking stops the scan.
+func (m *Manager) Scan(fsys fs.FS, root string) error {
+	errs := []error{}
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		err = m.Add(p)
+		if err != nil && !errors.Is(err, ErrNotSeqfile) {
+			errs = append(errs, fmt.Errorf("%s: %w", p, err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,34 @@
+package sequence
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestScan(t *testing.T) {
+	fsys := fstest.MapFS{
+		"renders/img.0001.exr":     {},
+		"renders/img.0002.exr":     {},
+		"renders/img.0004.exr":     {},
+		"renders/notes.txt":        {},
+		"renders/sub/mask.001.png": {},
+		"other/img.0001.exr":       {},
+	}
+	man := NewManager(DefaultSplitter, FmtSharp)
+	if err := man.Scan(fsys, "renders"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := "renders/img.####.exr 1-2 4\nrenders/sub/mask.###.png 1"
+	if got := man.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	if err := man.Scan(fsys, "none"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("got err: %v, want: %v", err, fs.ErrNotExist)
+	}
+	if err := man.Scan(fsys, "renders"); !errors.Is(err, ErrFrameExists) {
+		t.Fatalf("got err: %v, want: %v", err, ErrFrameExists)
+	}
+}
@@ -0,0 +1,41 @@
+package sequence
+
+import "strings"
+
+// SelectFunc chooses which digit group of a file name is the frame.
+// It gets the candidates from left to right, and returns index of the chosen one.
+// It could return -1, when the file should not be treated as a sequence file.
+type SelectFunc func(cands []Candidate) int
+
+// WithSelect returns a copy of the splitter, that finds all digit groups
+// of a file's base name and lets fn choose the frame,
+// instead of using it's regular expression.
+//
+// In strict mode, the splitter returns AmbiguousNameError
+// when fn could not choose one.
+func (s *Splitter) WithSelect(fn SelectFunc) *Splitter {
+	c := *s
+	c.selectFrame = fn
+	return &c
+}
+
+// SelectLeftmost chooses the left most digit group.
+func SelectLeftmost(cands []Candidate) int {
+	return 0
+}
+
+// SelectRightmost chooses the right most digit group.
+func SelectRightmost(cands []Candidate) int {
+	return len(cands) - 1
+}
+
+// SelectNearestExt chooses the right most digit group before the extension,
+// so "img.0001.jp2" is frame 1 of "img.####.jp2", not frame 2.
+func SelectNearestExt(cands []Candidate) int {
+	for i := len(cands) - 1; i >= 0; i-- {
+		if strings.Contains(cands[i].Post, ".") {
+			return i
+		}
+	}
+	return -1
+}
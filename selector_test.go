@@ -0,0 +1,55 @@
+package sequence
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSplitterSelect(t *testing.T) {
+	byDot := func(cands []Candidate) int {
+		for i, c := range cands {
+			if strings.HasSuffix(c.Pre, ".") {
+				return i
+			}
+		}
+		return -1
+	}
+	cases := []struct {
+		fn      SelectFunc
+		fname   string
+		want    string
+		wantErr error
+	}{
+		{fn: SelectRightmost, fname: "/a/shot010_img.0001_v002.exr", want: "/a/shot010_img.0001_v###.exr"},
+		{fn: SelectLeftmost, fname: "/a/shot010_img.0001_v002.exr", want: "/a/shot###_img.0001_v002.exr"},
+		{fn: SelectNearestExt, fname: "/a/shot010_img.0001_v002.exr", want: "/a/shot010_img.0001_v###.exr"},
+		{fn: SelectNearestExt, fname: "/a/img.0001.jp2", want: "/a/img.####.jp2"},
+		{fn: SelectRightmost, fname: "/a/img.0001.jp2", want: "/a/img.0001.jp#"},
+		{fn: byDot, fname: "/a/shot010_img.0001_v002.exr", want: "/a/shot010_img.####_v002.exr"},
+		{fn: byDot, fname: "/a/shot010.exr", wantErr: ErrNotSeqfile},
+		{fn: SelectLeftmost, fname: "/a1/img.exr", wantErr: ErrNotSeqfile},
+	}
+	for _, c := range cases {
+		s := DefaultSplitter.WithSelect(c.fn)
+		pre, digits, post, err := s.Split(c.fname)
+		if !errors.Is(err, c.wantErr) {
+			t.Fatalf("%q: got err: %v, want: %v", c.fname, err, c.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if got := FmtSharp.Format(pre, digits, post); got != c.want {
+			t.Fatalf("got: %q, want: %q", got, c.want)
+		}
+	}
+
+	s := DefaultSplitter.WithSelect(byDot).WithStrict()
+	var ambErr *AmbiguousNameError
+	if _, _, _, err := s.Split("/a/shot010.exr"); !errors.As(err, &ambErr) {
+		t.Fatalf("got err: %v, want: AmbiguousNameError", err)
+	}
+	if got := len(s.Candidates("/a/shot010_img.0001_v002.exr")); got != 3 {
+		t.Fatalf("got: %d candidates, want: 3", got)
+	}
+}
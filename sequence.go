@@ -3,16 +3,26 @@ package sequence
 import (
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"path"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
 )
 
 var (
 	ErrNotSeqfile    = errors.New("not a sequence file")
 	ErrFrameExists   = errors.New("frame exists")
+	ErrFrameNotFound = errors.New("frame not found")
 	ErrNegativeFrame = errors.New("nagative frame")
+	ErrNameCollision = errors.New("sequence names collide")
+	ErrSeqNotFound   = errors.New("sequence not found")
+	ErrInvalidRange  = errors.New("invalid range")
 )
 
 // Splitter is a file name splitter.
@@ -23,6 +33,19 @@ type Splitter struct {
 	//
 	// Note: If it does not have 3 sub groups, it will panic.
 	re *regexp.Regexp
+
+	// strict makes Split return AmbiguousNameError,
+	// when a file name could be split in multiple ways.
+	strict bool
+
+	// negative makes Split capture a minus sign before the digits.
+	negative bool
+
+	// selectFrame chooses the frame from digit groups, instead of re.
+	selectFrame SelectFunc
+
+	// fps makes the digits interpreted as HHMMSSFF timecode, if not 0.
+	fps int
 }
 
 // reDefaultSplit is regular expression for DefaultSplitter.
@@ -47,46 +70,627 @@ func NewSplitter(re *regexp.Regexp) *Splitter {
 	}
 }
 
+// WithStrict returns a copy of the splitter in strict mode.
+//
+// In strict mode, Split does not guess when the base name of a file
+// has multiple digit groups or it's only digit group looks like a version
+// (like "v001"). It returns an AmbiguousNameError instead.
+func (s *Splitter) WithStrict() *Splitter {
+	c := *s
+	c.strict = true
+	return &c
+}
+
 // Split takes a file name and splits it into 3 parts,
 // which is pre, digits, and post.
 // It returns error if the file name does not look like a sequence file.
 func (s *Splitter) Split(fname string) (pre, digits, post string, err error) {
-	m := s.re.FindStringSubmatch(fname)
-	if m == nil {
-		return "", "", "", ErrNotSeqfile
+	if s.selectFrame != nil {
+		cands := candidates(fname)
+		i := -1
+		if len(cands) != 0 {
+			i = s.selectFrame(cands)
+		}
+		if i < 0 || i >= len(cands) {
+			if s.strict && len(cands) != 0 {
+				return "", "", "", &AmbiguousNameError{Name: fname, Candidates: cands}
+			}
+			return "", "", "", ErrNotSeqfile
+		}
+		pre, digits, post = cands[i].Pre, cands[i].Digits, cands[i].Post
+	} else {
+		m := s.re.FindStringSubmatch(fname)
+		if m == nil {
+			return "", "", "", ErrNotSeqfile
+		}
+		if s.strict {
+			if cands := candidates(fname); len(cands) > 1 || isVersionLike(cands) {
+				return "", "", "", &AmbiguousNameError{Name: fname, Candidates: cands}
+			}
+		}
+		pre, digits, post = m[1], m[2], m[3]
 	}
-	return m[1], m[2], m[3], nil
+	if s.negative && strings.HasSuffix(pre, "-") {
+		p := pre[:len(pre)-1]
+		if p == "" || strings.ContainsAny(p[len(p)-1:], `._/\`) {
+			pre, digits = p, "-"+digits
+		}
+	}
+	return pre, digits, post, nil
+}
+
+// WithNegative returns a copy of the splitter that captures negative frames,
+// like "img.-0005.exr". The minus sign should follow '.', '_' or a path
+// separator, so "img-0005.exr" is still frame 5 of "img-####.exr".
+//
+// A manager using the splitter allows negative frames in it's sequences.
+// It does not count the minus sign as padding, so "img.-0005.exr"
+// and "img.0005.exr" are in a same sequence.
+func (s *Splitter) WithNegative() *Splitter {
+	c := *s
+	c.negative = true
+	return &c
+}
+
+// Confidence splits a file name like Split does,
+// and scores how likely the chosen digits are the frame, from 0 to 1.
+//
+// The score gets higher when the digits are delimited by '.' or '_',
+// followed directly by the extension, and at least 3 digits long.
+// It gets lower when the digits look like a version,
+// or the base name has other digit groups.
+func (s *Splitter) Confidence(fname string) (float64, error) {
+	pre, digits, post, err := s.Split(fname)
+	if err != nil {
+		return 0, err
+	}
+	c := 0.4
+	if pre == "" || strings.HasSuffix(pre, ".") || strings.HasSuffix(pre, "_") ||
+		strings.HasSuffix(pre, "/") || strings.HasSuffix(pre, `\`) {
+		c += 0.2
+	}
+	if post == "" || (strings.HasPrefix(post, ".") && !strings.Contains(post[1:], ".")) {
+		c += 0.2
+	}
+	if len(digits) >= 3 {
+		c += 0.2
+	}
+	cands := candidates(fname)
+	if isVersionLike(cands) {
+		c -= 0.3
+	}
+	if len(cands) > 1 {
+		c *= 0.75
+	}
+	return math.Max(0, math.Min(1, c)), nil
+}
+
+// A Candidate is a possible interpretation of a file name's parts.
+type Candidate struct {
+	Pre    string
+	Digits string
+	Post   string
+}
+
+// AmbiguousNameError is returned by a strict splitter,
+// when it could not decide which digit group is the frame.
+type AmbiguousNameError struct {
+	Name       string
+	Candidates []Candidate
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("ambiguous name %q: %d candidate(s)", e.Name, len(e.Candidates))
+}
+
+// Candidates returns all digit groups of the file's base name
+// as possible interpretations, from left to right.
+func (s *Splitter) Candidates(fname string) []Candidate {
+	return candidates(fname)
+}
+
+// reDigits finds digit groups in a file name.
+var reDigits = regexp.MustCompile(`\d+`)
+
+// candidates returns all interpretations of the file's base name,
+// from left to right.
+func candidates(fname string) []Candidate {
+	base := strings.LastIndexAny(fname, `/\`) + 1
+	cands := []Candidate{}
+	for _, loc := range reDigits.FindAllStringIndex(fname[base:], -1) {
+		i, j := base+loc[0], base+loc[1]
+		cands = append(cands, Candidate{Pre: fname[:i], Digits: fname[i:j], Post: fname[j:]})
+	}
+	return cands
+}
+
+// isVersionLike reports whether the only candidate is led by 'v' or 'V'.
+func isVersionLike(cands []Candidate) bool {
+	if len(cands) != 1 {
+		return false
+	}
+	pre := cands[0].Pre
+	return strings.HasSuffix(pre, "v") || strings.HasSuffix(pre, "V")
+}
+
+// A Formatter formats split parts of a file to a sequence name,
+// and parses the name back to it's key.
+type Formatter interface {
+	// Format returns a sequence name of the parts.
+	Format(pre, digits, post string) string
+
+	// Parse parses a sequence name made by Format,
+	// like "img.%04d.exr" to Key{Pre: "img.", Pad: 4, Post: ".exr"}.
+	Parse(name string) (Key, error)
+}
+
+// NewFormatter creates a formatter from format and parse functions.
+func NewFormatter(format func(pre, digits, post string) string, parse func(name string) (Key, error)) Formatter {
+	return funcFormatter{format: format, parse: parse}
+}
+
+// funcFormatter is a formatter made of functions.
+type funcFormatter struct {
+	format func(pre, digits, post string) string
+	parse  func(name string) (Key, error)
+}
+
+func (f funcFormatter) Format(pre, digits, post string) string {
+	return f.format(pre, digits, post)
+}
+
+func (f funcFormatter) Parse(name string) (Key, error) {
+	return f.parse(name)
 }
 
 // Fmt{Sharp, DollarF, PrecentD} are pre-defined formatter,
 // that covers most user's need.
 var (
-	FmtSharp = func(pre, digits, post string) string {
-		return pre + strings.Repeat("#", len(digits)) + post
+	FmtSharp = NewFormatter(
+		func(pre, digits, post string) string {
+			return pre + strings.Repeat("#", len(digits)) + post
+		},
+		tokenParser(regexp.MustCompile(`#+`)),
+	)
+	FmtDollarF = NewFormatter(
+		func(pre, digits, post string) string {
+			return pre + "$F" + strconv.Itoa(len(digits)) + post
+		},
+		tokenParser(regexp.MustCompile(`\$F(\d+)`)),
+	)
+	FmtPercentD = NewFormatter(
+		func(pre, digits, post string) string {
+			return pre + "%0" + strconv.Itoa(len(digits)) + "d" + post
+		},
+		tokenParser(regexp.MustCompile(`%0(\d+)d`)),
+	)
+)
+
+// More pre-defined formatters for other applications.
+//
+// FmtAt uses Katana style "@@@@" padding. FmtDollarFPlain uses "$F",
+// which does not have a width, so files with different padding
+// get a same name. FmtFFmpeg is like FmtPercentD, but escapes '%'
+// in the parts as "%%", so the name could be given to "ffmpeg -i" as is.
+var (
+	FmtAt = NewFormatter(
+		func(pre, digits, post string) string {
+			return pre + strings.Repeat("@", len(digits)) + post
+		},
+		tokenParser(regexp.MustCompile(`@+`)),
+	)
+	FmtDollarFPlain = NewFormatter(
+		func(pre, digits, post string) string {
+			return pre + "$F" + post
+		},
+		func(name string) (Key, error) {
+			i := strings.LastIndex(name, "$F")
+			if i < 0 || (i+2 < len(name) && name[i+2] >= '0' && name[i+2] <= '9') {
+				return Key{}, fmt.Errorf("%w: %s", ErrNoFrameToken, name)
+			}
+			return Key{Pre: name[:i], Pad: 0, Post: name[i+2:]}, nil
+		},
+	)
+	FmtFFmpeg = NewFormatter(
+		func(pre, digits, post string) string {
+			esc := strings.NewReplacer("%", "%%")
+			return esc.Replace(pre) + "%0" + strconv.Itoa(len(digits)) + "d" + esc.Replace(post)
+		},
+		parseFFmpeg,
+	)
+)
+
+// reFFmpegToken finds escaped percent signs and frame tokens of ffmpeg patterns.
+var reFFmpegToken = regexp.MustCompile(`%%|%0?(\d*)d`)
+
+// parseFFmpeg parses a name made by FmtFFmpeg.
+func parseFFmpeg(name string) (Key, error) {
+	var loc []int
+	for _, l := range reFFmpegToken.FindAllStringSubmatchIndex(name, -1) {
+		if name[l[0]:l[1]] != "%%" {
+			loc = l
+		}
 	}
-	FmtDollarF = func(pre, digits, post string) string {
-		return pre + "$F" + strconv.Itoa(len(digits)) + post
+	if loc == nil {
+		return Key{}, fmt.Errorf("%w: %s", ErrNoFrameToken, name)
 	}
-	FmtPercentD = func(pre, digits, post string) string {
-		return pre + "%0" + strconv.Itoa(len(digits)) + "d" + post
+	pad, _ := strconv.Atoi("0" + name[loc[2]:loc[3]])
+	unesc := strings.NewReplacer("%%", "%")
+	return Key{Pre: unesc.Replace(name[:loc[0]]), Pad: pad, Post: unesc.Replace(name[loc[1]:])}, nil
+}
+
+// tokenParser returns a parse function that finds the right most frame token
+// of a name with re. The padding is the token's length,
+// or the number in it's first sub group if it has one.
+func tokenParser(re *regexp.Regexp) func(name string) (Key, error) {
+	return func(name string) (Key, error) {
+		locs := re.FindAllStringSubmatchIndex(name, -1)
+		if len(locs) == 0 {
+			return Key{}, fmt.Errorf("%w: %s", ErrNoFrameToken, name)
+		}
+		loc := locs[len(locs)-1]
+		pad := loc[1] - loc[0]
+		if len(loc) > 2 {
+			pad, _ = strconv.Atoi(name[loc[2]:loc[3]])
+		}
+		return Key{Pre: name[:loc[0]], Pad: pad, Post: name[loc[1]:]}, nil
 	}
-)
+}
+
+// SlashFormatter wraps a formatter, so the names always use
+// forward slashes as path separators, regardless of the platform.
+// It makes listings from Windows and Linux machines match.
+func SlashFormatter(f Formatter) Formatter {
+	return NewFormatter(
+		func(pre, digits, post string) string {
+			return f.Format(toSlash(pre), digits, toSlash(post))
+		},
+		f.Parse,
+	)
+}
+
+// NativeFormatter wraps a formatter, so the names always use
+// the platform's path separators, whichever separators the files used.
+func NativeFormatter(f Formatter) Formatter {
+	return NewFormatter(
+		func(pre, digits, post string) string {
+			return f.Format(filepath.FromSlash(toSlash(pre)), digits, filepath.FromSlash(toSlash(post)))
+		},
+		f.Parse,
+	)
+}
+
+// toSlash replaces both back slashes and the platform's separators
+// with forward slashes.
+func toSlash(p string) string {
+	return filepath.ToSlash(strings.ReplaceAll(p, `\`, "/"))
+}
+
+// A Key identifies a sequence by it's split parts,
+// so it does not depend on formatter.
+type Key struct {
+	Pre  string
+	Pad  int
+	Post string
+
+	// FPS is the frame rate of a sequence named by timecode.
+	// It is 0 for a sequence named by frame numbers.
+	FPS int
+}
+
+// Format formats the key with a formatter.
+//
+// As the key only knows the padding, the formatter will get
+// zeros of the padding width as digits.
+func (k Key) Format(f Formatter) string {
+	return f.Format(k.Pre, strings.Repeat("0", k.Pad), k.Post)
+}
+
+// FileName returns the file name of a frame in the sequence of the key.
+// A negative frame gets a minus sign before it's padded digits,
+// and a frame of a timecode key gets HHMMSSFF digits.
+func (k Key) FileName(f int) string {
+	if k.FPS != 0 {
+		return k.Pre + formatTimecode(f, k.FPS) + k.Post
+	}
+	if f < 0 {
+		return fmt.Sprintf("%s-%0*d%s", k.Pre, k.Pad, -f, k.Post)
+	}
+	return fmt.Sprintf("%s%0*d%s", k.Pre, k.Pad, f, k.Post)
+}
 
 // A Manager is a sequence manager.
+//
+// It is safe to call it's methods from multiple goroutines,
+// so several goroutines could scan different directories into a manager.
 type Manager struct {
+	// Seqs holds sequences by their names.
+	// It should be treated as read only, use Add to add files.
+	//
+	// Accessing it directly is not safe while other goroutines
+	// are adding files. Use Seq in that case.
 	Seqs map[string]*Seq
 
-	splitter   *Splitter
-	formatting func(pre, digits, post string) string
+	mu sync.RWMutex
+
+	// keys holds the key of each sequence name.
+	keys map[string]Key
+
+	// names holds sequence names in ascending order of less,
+	// as of the last sortedNames call. It is replaced, not modified,
+	// so it could be shared while the lock is held.
+	names []string
+
+	// added holds sequence names added after names was sorted,
+	// and removed reports whether any name was removed after that.
+	// They are merged into names lazily, so adding many sequences
+	// does not copy names for each of them.
+	added   []string
+	removed bool
+
+	// nmu guards names, added and removed, as readers merge them
+	// while holding the read lock.
+	nmu sync.Mutex
+
+	splitter  *Splitter
+	formatter Formatter
+
+	// normalize normalizes pre and post parts for names, if not nil.
+	normalize func(string) string
+
+	// padPolicy decides how files with different padding are grouped.
+	padPolicy PadPolicy
+
+	// groups holds the sequence name of each (pre, post) group,
+	// when the pad policy merges them.
+	groups map[string]string
+
+	// widths holds digit widths of each sequence's frames,
+	// when the pad policy merges them.
+	widths map[string]map[int]int
+
+	// views holds stereo view tokens those will be collapsed into "%V".
+	views []string
+
+	// less orders sequence names, if not nil. Otherwise names are sorted lexically.
+	less func(a, b string) bool
+
+	// parts holds the original pre and post parts of frames those differ
+	// from their sequence's key, like files in another unicode
+	// normalization form. So file names of the frames could be rebuilt.
+	parts map[string]map[int]nameParts
+}
+
+// nameParts are pre and post parts of a file name.
+type nameParts struct {
+	pre  string
+	post string
 }
 
 // NewManager creates a new sequence manager.
-func NewManager(splitter *Splitter, formatting func(pre, digits, post string) string) *Manager {
+func NewManager(splitter *Splitter, formatter Formatter) *Manager {
 	return &Manager{
-		Seqs:       make(map[string]*Seq),
-		splitter:   splitter,
-		formatting: formatting,
+		Seqs:      make(map[string]*Seq),
+		keys:      make(map[string]Key),
+		names:     []string{},
+		splitter:  splitter,
+		formatter: formatter,
+	}
+}
+
+// Clone returns a deep copy of the manager.
+// Mutating the copy does not affect the original, and vice versa.
+func (m *Manager) Clone() *Manager {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c := &Manager{
+		Seqs:      make(map[string]*Seq, len(m.Seqs)),
+		keys:      make(map[string]Key, len(m.keys)),
+		names:     m.sortedNames(),
+		splitter:  m.splitter,
+		formatter: m.formatter,
+		normalize: m.normalize,
+		padPolicy: m.padPolicy,
+		views:     m.views,
+		less:      m.less,
+	}
+	for n, s := range m.Seqs {
+		c.Seqs[n] = s.Clone()
+	}
+	for n, k := range m.keys {
+		c.keys[n] = k
+	}
+	if m.padPolicy != PadStrict {
+		c.groups = make(map[string]string, len(m.groups))
+		for g, n := range m.groups {
+			c.groups[g] = n
+		}
+		c.widths = make(map[string]map[int]int, len(m.widths))
+		for n, ws := range m.widths {
+			c.widths[n] = make(map[int]int, len(ws))
+			for f, w := range ws {
+				c.widths[n][f] = w
+			}
+		}
+	}
+	if m.parts != nil {
+		c.parts = make(map[string]map[int]nameParts, len(m.parts))
+		for n, ps := range m.parts {
+			c.parts[n] = make(map[int]nameParts, len(ps))
+			for f, p := range ps {
+				c.parts[n][f] = p
+			}
+		}
+	}
+	return c
+}
+
+// Seq returns a copy of a sequence, which is safe to use
+// while other goroutines are adding files.
+func (m *Manager) Seq(name string) (*Seq, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.Seqs[name]
+	if !ok {
+		return nil, false
 	}
+	return s.Clone(), true
+}
+
+// A Sequence is a sequence with it's name and parts,
+// so users do not have to re-parse the name.
+type Sequence struct {
+	// Name is the formatted name, like "/a/img.####.exr".
+	Name string
+
+	// Dir is the directory of the files, like "/a".
+	Dir string
+
+	// Base is the part of the base name before the frame, like "img.".
+	Base string
+
+	// Ext is the extension of the files, like ".exr".
+	Ext string
+
+	// Padding is the width of the frame digits.
+	Padding int
+
+	// Key holds the exact parts of the first added file.
+	Key Key
+
+	// Frames is a copy of the sequence's frames.
+	Frames *Seq
+}
+
+// Sequence returns a sequence with it's name and parts.
+func (m *Manager) Sequence(name string) (*Sequence, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if _, ok := m.Seqs[name]; !ok {
+		return nil, false
+	}
+	return m.sequence(name), true
+}
+
+// Sequences returns all sequences in name order.
+func (m *Manager) Sequences() []*Sequence {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := m.sortedNames()
+	seqs := make([]*Sequence, 0, len(names))
+	for _, n := range names {
+		seqs = append(seqs, m.sequence(n))
+	}
+	return seqs
+}
+
+// sequence makes a Sequence of a name. The caller should hold the lock.
+func (m *Manager) sequence(name string) *Sequence {
+	k := m.keys[name]
+	i := strings.LastIndexAny(k.Pre, `/\`)
+	dir, base := "", k.Pre
+	if i >= 0 {
+		dir, base = k.Pre[:i], k.Pre[i+1:]
+		if dir == "" {
+			dir = k.Pre[:1]
+		}
+	}
+	return &Sequence{
+		Name:    name,
+		Dir:     dir,
+		Base:    base,
+		Ext:     filepath.Ext(k.Post),
+		Padding: k.Pad,
+		Key:     k,
+		Frames:  m.Seqs[name].Clone(),
+	}
+}
+
+// Key returns the key of a sequence.
+//
+// When the formatter gives a same name to different keys,
+// the key of the first added file will be returned.
+func (m *Manager) Key(name string) (Key, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	k, ok := m.keys[name]
+	return k, ok
+}
+
+// Rekey re-renders all sequence names with a new formatter,
+// which will be used for the files added after.
+//
+// It returns ErrNameCollision and does nothing
+// when the new formatter gives a same name to different sequences.
+func (m *Manager) Rekey(formatter Formatter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	seqs := make(map[string]*Seq)
+	keys := make(map[string]Key)
+	names := []string{}
+	renamed := make(map[string]string)
+	for n, s := range m.Seqs {
+		k := m.keys[n]
+		name := formatter.Format(m.norm(k.Pre), strings.Repeat("0", k.Pad), m.norm(k.Post))
+		if _, ok := seqs[name]; ok {
+			return fmt.Errorf("%w: %s", ErrNameCollision, name)
+		}
+		seqs[name] = s
+		keys[name] = k
+		names = append(names, name)
+		renamed[n] = name
+	}
+	sort.Slice(names, func(i, j int) bool { return m.lessName(names[i], names[j]) })
+	if m.parts != nil {
+		parts := make(map[string]map[int]nameParts, len(m.parts))
+		for old, ps := range m.parts {
+			parts[renamed[old]] = ps
+		}
+		m.parts = parts
+	}
+	if m.padPolicy != PadStrict {
+		widths := make(map[string]map[int]int)
+		for old, name := range renamed {
+			m.groups[m.groupID(keys[name])] = name
+			widths[name] = m.widths[old]
+		}
+		m.widths = widths
+	}
+	m.Seqs = seqs
+	m.keys = keys
+	m.setNames(names)
+	m.formatter = formatter
+	return nil
+}
+
+// SetNormalizer sets a function that normalizes pre and post parts
+// before they are formatted to a sequence name, like norm.NFC.String
+// from golang.org/x/text/unicode/norm. So file names those differ only
+// in their unicode normalization form will be in a same sequence.
+//
+// Keys still hold the parts of the first added file as is,
+// and the manager keeps the parts of other files those differ from it,
+// for operations that need the real file names, like Files.
+//
+// It should be set before adding files. Nil disables normalization.
+func (m *Manager) SetNormalizer(normalize func(string) string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.normalize = normalize
+}
+
+// norm normalizes a string with the manager's normalizer.
+func (m *Manager) norm(s string) string {
+	if m.normalize == nil {
+		return s
+	}
+	return m.normalize(s)
+}
+
+// name returns a sequence name of the parts.
+// The caller should hold the lock.
+func (m *Manager) name(pre, digits, post string) string {
+	return m.formatter.Format(m.norm(pre), digits, m.norm(post))
 }
 
 // Add adds a file to the manager.
@@ -94,41 +698,530 @@ func NewManager(splitter *Splitter, formatting func(pre, digits, post string) st
 // If the file's sequence is not exist yet,
 // it will create a new sequence automatically.
 func (m *Manager) Add(fname string) error {
-	pre, digits, post, err := m.splitter.Split(fname)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.addFile(fname)
+}
+
+// addFile adds a file. The caller should hold the lock.
+func (m *Manager) addFile(fname string) error {
+	name, k, frame, view, err := m.split(fname)
 	if err != nil {
 		return err
 	}
+	if m.padPolicy != PadStrict {
+		return m.addMerged(name, k, frame, view)
+	}
+	if view != "" {
+		return m.addView(name, k, frame, view)
+	}
+	return m.add(name, k, frame)
+}
 
-	name := m.formatting(pre, digits, post)
-	frame, _ := strconv.Atoi(digits)
+// split splits a file name, and returns it's sequence name, key, frame
+// and stereo view. The view is empty when the file is not a stereo file.
+// The caller should hold the lock.
+func (m *Manager) split(fname string) (name string, k Key, frame int, view string, err error) {
+	pre, digits, post, err := m.splitter.Split(fname)
+	if err != nil {
+		return "", Key{}, 0, "", err
+	}
+	pre, view = m.splitView(pre)
+	if fps := m.splitter.fps; fps != 0 {
+		frame, err = parseTimecode(digits, fps)
+		if err != nil {
+			return "", Key{}, 0, "", err
+		}
+		k = Key{Pre: pre, Pad: len(digits), Post: post, FPS: fps}
+		return m.name(pre, digits, post), k, frame, view, nil
+	}
+	frame, _ = strconv.Atoi(digits)
+	// Padding does not count the minus sign of a negative frame,
+	// so the frame belongs to the same sequence as positive ones.
+	digits = strings.TrimPrefix(digits, "-")
+	k = Key{Pre: pre, Pad: len(digits), Post: post}
+	if m.padPolicy != PadStrict {
+		if name, ok := m.groups[m.groupID(k)]; ok {
+			return name, k, frame, view, nil
+		}
+	}
+	return m.name(pre, digits, post), k, frame, view, nil
+}
 
+// add adds a frame to a sequence, creating the sequence if needed.
+// The caller should hold the lock.
+func (m *Manager) add(name string, k Key, frame int) error {
 	s, ok := m.Seqs[name]
 	if !ok {
+		s = m.addSeq(name, k)
+	}
+	if err := s.AddFrame(frame); err != nil {
+		return err
+	}
+	if sk := m.keys[name]; k.Pre != sk.Pre || k.Post != sk.Post {
+		if m.parts == nil {
+			m.parts = make(map[string]map[int]nameParts)
+		}
+		if m.parts[name] == nil {
+			m.parts[name] = make(map[int]nameParts)
+		}
+		m.parts[name][frame] = nameParts{pre: k.Pre, post: k.Post}
+	}
+	return nil
+}
+
+// addSeq adds an empty sequence of the key, and returns it.
+// The caller should hold the lock.
+func (m *Manager) addSeq(name string, k Key) *Seq {
+	s := NewSeq()
+	s.allowNegative = m.splitter.negative
+	m.Seqs[name] = s
+	m.keys[name] = k
+	m.addName(name)
+	return s
+}
+
+// frameKey returns the key of a frame's file in a sequence.
+// It has the frame's own parts when they differ from the sequence's key,
+// and the frame's own digit width when the pad policy merged it.
+// The caller should hold the lock.
+func (m *Manager) frameKey(name string, frame int) Key {
+	k := m.keys[name]
+	if w, ok := m.widths[name][frame]; ok {
+		k.Pad = w
+	}
+	if p, ok := m.parts[name][frame]; ok {
+		k.Pre, k.Post = p.pre, p.post
+	}
+	return k
+}
+
+// frameState is per-frame state of a sequence those make it's file names,
+// with frames grouped by their values for encoding.
+type frameState struct {
+	// views holds frames of each stereo view.
+	views map[string]*Seq
+
+	// widths holds frames of each digit width,
+	// those differ from the padding of the sequence.
+	widths map[int]*Seq
+
+	// parts holds frames of each original name parts.
+	parts map[nameParts]*Seq
+}
+
+// withFrame adds a frame to s, creating s if it is nil, and returns it.
+func withFrame(s *Seq, f int) *Seq {
+	if s == nil {
 		s = NewSeq()
-		m.Seqs[name] = s
+		s.SetAllowNegative(true)
+	}
+	s.AddFrame(f)
+	return s
+}
+
+// frameState returns the per-frame state of a sequence.
+// The caller should hold the lock.
+func (m *Manager) frameState(name string) frameState {
+	st := frameState{
+		views:  make(map[string]*Seq),
+		widths: make(map[int]*Seq),
+		parts:  make(map[nameParts]*Seq),
+	}
+	for f, vs := range m.Seqs[name].views {
+		for v := range vs {
+			st.views[v] = withFrame(st.views[v], f)
+		}
+	}
+	pad := m.keys[name].Pad
+	for f, w := range m.widths[name] {
+		if w != pad {
+			st.widths[w] = withFrame(st.widths[w], f)
+		}
+	}
+	for f, p := range m.parts[name] {
+		st.parts[p] = withFrame(st.parts[p], f)
 	}
-	return s.AddFrame(frame)
+	return st
+}
+
+// sortedViews returns the views of the state in order.
+func (st frameState) sortedViews() []string {
+	vs := make([]string, 0, len(st.views))
+	for v := range st.views {
+		vs = append(vs, v)
+	}
+	sort.Strings(vs)
+	return vs
+}
+
+// sortedWidths returns the widths of the state in order.
+func (st frameState) sortedWidths() []int {
+	ws := make([]int, 0, len(st.widths))
+	for w := range st.widths {
+		ws = append(ws, w)
+	}
+	sort.Ints(ws)
+	return ws
+}
+
+// sortedParts returns the name parts of the state in order.
+func (st frameState) sortedParts() []nameParts {
+	ps := make([]nameParts, 0, len(st.parts))
+	for p := range st.parts {
+		ps = append(ps, p)
+	}
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].pre != ps[j].pre {
+			return ps[i].pre < ps[j].pre
+		}
+		return ps[i].post < ps[j].post
+	})
+	return ps
+}
+
+// addKey adds frames of a sequence by it's key and per-frame state,
+// and returns the errors of the frames those could not be added.
+// Nil frames in the state are skipped. The caller should hold the lock.
+func (m *Manager) addKey(k Key, s *Seq, st frameState) []error {
+	views := make(map[int][]string)
+	for _, v := range st.sortedViews() {
+		if st.views[v] == nil {
+			continue
+		}
+		for f := range st.views[v].All() {
+			views[f] = append(views[f], v)
+		}
+	}
+	widths := make(map[int]int)
+	for w, ws := range st.widths {
+		if ws == nil {
+			continue
+		}
+		for f := range ws.All() {
+			widths[f] = w
+		}
+	}
+	parts := make(map[int]nameParts)
+	for p, ps := range st.parts {
+		if ps == nil {
+			continue
+		}
+		for f := range ps.All() {
+			parts[f] = p
+		}
+	}
+
+	errs := []error{}
+	added := []int{}
+	name := m.name(k.Pre, strings.Repeat("0", k.Pad), k.Post)
+	for f := range s.All() {
+		fk := k
+		if w, ok := widths[f]; ok {
+			fk.Pad = w
+		}
+		if p, ok := parts[f]; ok {
+			fk.Pre, fk.Post = p.pre, p.post
+		}
+		fvs := views[f]
+		if len(fvs) == 0 {
+			fvs = []string{""}
+		}
+		ok := false
+		for _, v := range fvs {
+			var err error
+			if m.padPolicy != PadStrict {
+				err = m.addMerged(name, k, f, v)
+			} else if v != "" {
+				err = m.addView(name, k, f, v)
+			} else {
+				err = m.add(name, k, f)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", viewFileName(fk, v, f), err))
+				continue
+			}
+			ok = true
+		}
+		if ok {
+			added = append(added, f)
+		}
+	}
+
+	// The frames are added with the sequence's key,
+	// then get their own widths and parts back.
+	if m.padPolicy != PadStrict {
+		if n, ok := m.groups[m.groupID(k)]; ok {
+			name = n
+		}
+	}
+	for _, f := range added {
+		if w, ok := widths[f]; ok {
+			if m.widths == nil {
+				m.widths = make(map[string]map[int]int)
+			}
+			if m.widths[name] == nil {
+				m.widths[name] = make(map[int]int)
+			}
+			m.widths[name][f] = w
+		}
+		if p, ok := parts[f]; ok {
+			if m.parts == nil {
+				m.parts = make(map[string]map[int]nameParts)
+			}
+			if m.parts[name] == nil {
+				m.parts[name] = make(map[int]nameParts)
+			}
+			m.parts[name][f] = p
+		}
+	}
+	return errs
+}
+
+// Remove removes a file from the manager.
+//
+// When the last frame of a sequence is removed,
+// the sequence is removed as well.
+func (m *Manager) Remove(fname string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.removeFile(fname)
+}
+
+// removeFile removes a file. The caller should hold the lock.
+func (m *Manager) removeFile(fname string) error {
+	name, _, frame, view, err := m.split(fname)
+	if err != nil {
+		return err
+	}
+	if view != "" {
+		return m.removeView(name, frame, view)
+	}
+	return m.remove(name, frame)
+}
+
+// remove removes a frame from a sequence,
+// and removes the sequence if it gets empty.
+// The caller should hold the lock.
+func (m *Manager) remove(name string, frame int) error {
+	s, ok := m.Seqs[name]
+	if !ok {
+		return ErrFrameNotFound
+	}
+	if err := s.RemoveFrame(frame); err != nil {
+		return err
+	}
+	delete(m.widths[name], frame)
+	delete(m.parts[name], frame)
+	if s.frames.len() == 0 {
+		delete(m.parts, name)
+		delete(m.widths, name)
+		if m.padPolicy != PadStrict {
+			delete(m.groups, m.groupID(m.keys[name]))
+		}
+		delete(m.Seqs, name)
+		delete(m.keys, name)
+		m.removeName()
+	}
+	return nil
+}
+
+// addName adds a sequence name to the name index.
+// The caller should hold the lock.
+func (m *Manager) addName(name string) {
+	m.nmu.Lock()
+	defer m.nmu.Unlock()
+	m.added = append(m.added, name)
+}
+
+// removeName marks that a sequence is removed from the name index.
+// The sequence should be removed from Seqs already.
+// The caller should hold the lock.
+func (m *Manager) removeName() {
+	m.nmu.Lock()
+	defer m.nmu.Unlock()
+	m.removed = true
+}
+
+// setNames replaces the name index with sorted names.
+// The caller should hold the lock.
+func (m *Manager) setNames(names []string) {
+	m.nmu.Lock()
+	defer m.nmu.Unlock()
+	m.names, m.added, m.removed = names, nil, false
+}
+
+// sortedNames returns sequence names in ascending order,
+// merging names those are added or removed after the last call.
+// The returned names should not be modified.
+// The caller should hold the lock, at least the read lock.
+func (m *Manager) sortedNames() []string {
+	m.nmu.Lock()
+	defer m.nmu.Unlock()
+	if len(m.added) == 0 && !m.removed {
+		return m.names
+	}
+	added := m.added
+	sort.Slice(added, func(i, j int) bool { return m.lessName(added[i], added[j]) })
+	names := make([]string, 0, len(m.Seqs))
+	i, j := 0, 0
+	for i < len(m.names) || j < len(added) {
+		var n string
+		if j == len(added) || (i < len(m.names) && !m.lessName(added[j], m.names[i])) {
+			n = m.names[i]
+			i++
+		} else {
+			n = added[j]
+			j++
+		}
+		// A name could be removed, or be removed and added again.
+		if _, ok := m.Seqs[n]; !ok {
+			continue
+		}
+		if len(names) != 0 && names[len(names)-1] == n {
+			continue
+		}
+		names = append(names, n)
+	}
+	m.names, m.added, m.removed = names, nil, false
+	return names
 }
 
 // SeqNames returns it's sequence names in ascending order.
+// Names are sorted lexically unless the manager has it's own order,
+// see SetLess.
+//
+// It returns a copy, so it will not change even if the manager is mutated after.
 func (m *Manager) SeqNames() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string{}, m.sortedNames()...)
+}
+
+// Page returns at most limit sequence names starting from offset,
+// in ascending order.
+func (m *Manager) Page(offset, limit int) []string {
+	if offset < 0 {
+		offset = 0
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pageOf(m.sortedNames(), offset, limit)
+}
+
+// pageOf returns a copy of at most limit names starting from offset.
+func (m *Manager) pageOf(names []string, offset, limit int) []string {
+	if offset >= len(names) || limit <= 0 {
+		return []string{}
+	}
+	end := offset + limit
+	if end > len(names) {
+		end = len(names)
+	}
+	return append([]string{}, names[offset:end]...)
+}
+
+// After returns at most limit sequence names that come after cursor,
+// in ascending order. Empty cursor starts from the first name.
+//
+// The last name of a result could be used as the cursor of the next call.
+// Unlike Page, it is not affected by sequences added before the cursor.
+func (m *Manager) After(cursor string, limit int) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := m.sortedNames()
+	i := m.searchName(names, cursor)
+	if i < len(names) && names[i] == cursor {
+		i++
+	}
+	return m.pageOf(names, i, limit)
+}
+
+// InDir returns names of sequences in a directory or it's sub directories,
+// in ascending order.
+//
+// The current directory "." has all relative sequences
+// those are not in a parent directory, like ones found by Scan(fsys, ".").
+func (m *Manager) InDir(dir string) []string {
+	dir = filepath.Clean(dir)
+	prefix := dir
+	if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+		prefix += string(filepath.Separator)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	names := []string{}
-	for n := range m.Seqs {
-		names = append(names, n)
+	for _, n := range m.sortedNames() {
+		d := filepath.Dir(m.keys[n].Pre + "_")
+		if dir == "." && !filepath.IsAbs(d) && d != ".." && !strings.HasPrefix(d, ".."+string(filepath.Separator)) {
+			names = append(names, n)
+			continue
+		}
+		if d == dir || strings.HasPrefix(d, prefix) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// Find returns names of sequences that match a glob pattern,
+// in ascending order. See path.Match for the pattern syntax.
+//
+// A pattern without '/' matches the base names of the sequences,
+// so "*.exr" finds exr sequences in any directory.
+//
+// It returns error when the pattern is malformed.
+func (m *Manager) Find(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	base := !strings.Contains(pattern, "/")
+	names := []string{}
+	for _, n := range m.SeqNames() {
+		target := n
+		if base {
+			target = n[strings.LastIndexAny(n, `/\`)+1:]
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			names = append(names, n)
+		}
+	}
+	return names, nil
+}
+
+// FindRegexp returns names of sequences that match a regular expression,
+// in ascending order.
+func (m *Manager) FindRegexp(re *regexp.Regexp) []string {
+	names := []string{}
+	for _, n := range m.SeqNames() {
+		if re.MatchString(n) {
+			names = append(names, n)
+		}
 	}
-	sort.Strings(names)
 	return names
 }
 
 // String returns a string that shows it's sequences.
+// UDIM sequences named by FmtUDIM show their tile counts instead of frames,
+// like "tex.<UDIM>.png 4 tiles".
 //
 // It will be multiple lines if it has more than one sequence.
 func (m *Manager) String() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	str := ""
-	for _, n := range m.SeqNames() {
+	for _, n := range m.sortedNames() {
 		if str != "" {
 			str += "\n"
 		}
+		if isUDIMName(n) {
+			str += fmt.Sprintf("%s %s", n, tileCount(m.Seqs[n]))
+			continue
+		}
+		if m.Seqs[n].Len() == 0 {
+			str += n
+			continue
+		}
 		str += fmt.Sprintf("%s %s", n, m.Seqs[n])
 	}
 	return str
@@ -136,74 +1229,456 @@ func (m *Manager) String() string {
 
 // A Seq is a frame sequence. It does not hold a sequence name.
 type Seq struct {
-	frames map[int]struct{}
+	// frames holds the frames as runs, rather than one by one.
+	frames frameSet
+
+	// gapTolerance is the number of missing frames
+	// that Ranges will bridge over.
+	gapTolerance int
+
+	// attempts holds render attempt counts of frames.
+	attempts map[int]int
+
+	// excluded holds frames that should never be rendered or copied.
+	excluded map[int]struct{}
+
+	// statuses holds render statuses of frames.
+	statuses map[int]Status
+
+	// allowNegative makes the sequence accept negative frames.
+	allowNegative bool
+
+	// views holds stereo views of frames.
+	views map[int]map[string]struct{}
 }
 
 // NewSeq creates a new sequence.
 func NewSeq() *Seq {
-	return &Seq{
-		frames: make(map[int]struct{}),
+	return &Seq{}
+}
+
+// Clone returns a deep copy of the sequence.
+func (s *Seq) Clone() *Seq {
+	c := NewSeq()
+	c.frames = s.frames.clone()
+	c.gapTolerance = s.gapTolerance
+	c.allowNegative = s.allowNegative
+	if s.attempts != nil {
+		c.attempts = make(map[int]int, len(s.attempts))
+		for f, n := range s.attempts {
+			c.attempts[f] = n
+		}
+	}
+	if s.excluded != nil {
+		c.excluded = make(map[int]struct{}, len(s.excluded))
+		for f := range s.excluded {
+			c.excluded[f] = struct{}{}
+		}
+	}
+	if s.statuses != nil {
+		c.statuses = make(map[int]Status, len(s.statuses))
+		for f, st := range s.statuses {
+			c.statuses[f] = st
+		}
 	}
+	if s.views != nil {
+		c.views = make(map[int]map[string]struct{}, len(s.views))
+		for f, vs := range s.views {
+			c.views[f] = make(map[string]struct{}, len(vs))
+			for v := range vs {
+				c.views[f][v] = struct{}{}
+			}
+		}
+	}
+	return c
+}
+
+// SetAllowNegative sets whether the sequence accepts negative frames,
+// which some pipelines use for pre-roll or handles.
+func (s *Seq) SetAllowNegative(allow bool) {
+	s.allowNegative = allow
 }
 
 // AddFrame adds a frame into sequence.
 //
-// It treats negative frames are invalid.
+// It treats negative frames are invalid unless they are allowed.
 // So returns ErrNegativeFrame when it takes a negative frame.
+// See SetAllowNegative.
 func (s *Seq) AddFrame(f int) error {
-	if f < 0 {
+	if f < 0 && !s.allowNegative {
 		return ErrNegativeFrame
 	}
-	if _, ok := s.frames[f]; ok {
+	if !s.frames.add(f) {
 		return ErrFrameExists
 	}
-	s.frames[f] = struct{}{}
 	return nil
 }
 
-// Ranges converts a sequence to several contiguous ranges.
+// RemoveFrame removes a frame from sequence.
+// It returns ErrFrameNotFound when the sequence does not have the frame.
+func (s *Seq) RemoveFrame(f int) error {
+	if !s.frames.remove(f) {
+		return ErrFrameNotFound
+	}
+	delete(s.views, f)
+	return nil
+}
+
+// Exclude marks frames that should never be rendered or copied,
+// like known bad plate frames. Excluded frames are not counted as missing.
+//
+// It does not remove the frames from the sequence.
+func (s *Seq) Exclude(frames *Seq) {
+	if s.excluded == nil {
+		s.excluded = make(map[int]struct{})
+	}
+	for f := range frames.frames.all() {
+		s.excluded[f] = struct{}{}
+	}
+}
+
+// Excluded reports whether a frame is excluded.
+func (s *Seq) Excluded(f int) bool {
+	_, ok := s.excluded[f]
+	return ok
+}
+
+// RecordAttempt records an attempt to render a frame,
+// and returns how many times the frame was attempted.
+// The frame does not have to be in the sequence.
+func (s *Seq) RecordAttempt(f int) int {
+	if s.attempts == nil {
+		s.attempts = make(map[int]int)
+	}
+	s.attempts[f]++
+	return s.attempts[f]
+}
+
+// Attempts returns how many times a frame was attempted.
+func (s *Seq) Attempts(f int) int {
+	return s.attempts[f]
+}
+
+// OverAttempted returns frames attempted more than n times,
+// in ascending order.
+func (s *Seq) OverAttempted(n int) []int {
+	frames := []int{}
+	for f, a := range s.attempts {
+		if a > n {
+			frames = append(frames, f)
+		}
+	}
+	sort.Ints(frames)
+	return frames
+}
+
+// SetGapTolerance makes Ranges treat gaps up to n missing frames
+// as contiguous. So with n=1, frames 1-10 and 12-20 become "1-20".
+//
+// It only affects how the sequence is expressed. The missing frames
+// are not added, and could be found with BridgedFrames.
+// The default is 0, which expresses the frames exactly.
+func (s *Seq) SetGapTolerance(n int) {
+	if n < 0 {
+		n = 0
+	}
+	s.gapTolerance = n
+}
+
+// BridgedFrames returns missing frames that Ranges bridged over
+// because of the gap tolerance, in ascending order.
+func (s *Seq) BridgedFrames() []int {
+	bridgedFrames := []int{}
+	if s.gapTolerance == 0 {
+		return bridgedFrames
+	}
+	spans := s.frames.spans
+	for i := 1; i < len(spans); i++ {
+		if bridged(spans[i-1], spans[i], s.gapTolerance) {
+			for f := spans[i-1].max + 1; f < spans[i].min; f++ {
+				bridgedFrames = append(bridgedFrames, f)
+			}
+		}
+	}
+	return bridgedFrames
+}
+
+// Ranges converts a sequence to several ranges.
+//
+// Frames with a constant stride become a stepped range, like "1-9x2",
+// when there are at least 3 of them.
+//
+// Gaps are bridged over if they are not bigger than the gap tolerance.
+// Stepped ranges are not detected when it has a gap tolerance.
+// See SetGapTolerance.
 func (s *Seq) Ranges() []*Range {
-	if len(s.frames) == 0 {
+	if s.gapTolerance == 0 {
+		return s.frames.steppedRanges()
+	}
+	return s.frames.contiguousRanges(s.gapTolerance)
+}
+
+// Gaps returns the missing frames between it's first and last frames,
+// as contiguous ranges. Excluded frames are not treated as missing.
+func (s *Seq) Gaps() []*Range {
+	min, max, ok := s.bounds()
+	if !ok {
 		return []*Range{}
 	}
+	gaps := s.missing(min, max)
+	return gaps.contiguousRanges(0)
+}
 
-	frames := []int{}
-	for f := range s.frames {
+// Missing returns frames from min to max that the sequence does not have,
+// in ascending order. Excluded frames are not treated as missing.
+func (s *Seq) Missing(min, max int) []int {
+	missing := []int{}
+	gaps := s.missing(min, max)
+	for f := range gaps.all() {
+		missing = append(missing, f)
+	}
+	return missing
+}
+
+// missing returns frames from min to max that the sequence does not have,
+// and are not excluded.
+func (s *Seq) missing(min, max int) frameSet {
+	gaps := s.frames.complement(min, max)
+	if len(s.excluded) == 0 {
+		return gaps
+	}
+	excluded := frameSet{}
+	for f := range s.excluded {
+		excluded.add(f)
+	}
+	return gaps.subtract(&excluded)
+}
+
+// Contains reports whether the sequence has the frame.
+func (s *Seq) Contains(f int) bool {
+	return s.frames.has(f)
+}
+
+// First returns the first frame of the sequence.
+// It returns false when the sequence is empty.
+func (s *Seq) First() (int, bool) {
+	min, _, ok := s.frames.bounds()
+	return min, ok
+}
+
+// Last returns the last frame of the sequence.
+// It returns false when the sequence is empty.
+func (s *Seq) Last() (int, bool) {
+	_, max, ok := s.frames.bounds()
+	return max, ok
+}
+
+// Len returns the number of frames in the sequence.
+func (s *Seq) Len() int {
+	return s.frames.len()
+}
+
+// bounds returns it's smallest and biggest frames.
+// It returns false when the sequence is empty.
+func (s *Seq) bounds() (min, max int, ok bool) {
+	return s.frames.bounds()
+}
+
+// sortedFrames returns it's frames in ascending order.
+func (s *Seq) sortedFrames() []int {
+	frames := make([]int, 0, s.frames.len())
+	for f := range s.frames.all() {
 		frames = append(frames, f)
 	}
-	sort.Ints(frames)
+	return frames
+}
+
+// SampleRandom picks n frames from the sequence randomly
+// and returns them in ascending order.
+//
+// The same seed always picks the same frames from the same sequence.
+// When n is bigger than it's frame count, it returns all the frames.
+func (s *Seq) SampleRandom(n int, seed int64) []int {
+	frames := s.sortedFrames()
+	if n >= len(frames) {
+		return frames
+	}
+	if n <= 0 {
+		return []int{}
+	}
+	r := rand.New(rand.NewSource(seed))
+	picked := []int{}
+	for _, i := range r.Perm(len(frames))[:n] {
+		picked = append(picked, frames[i])
+	}
+	sort.Ints(picked)
+	return picked
+}
+
+// AtFraction returns the frame at a normalized position of the sequence.
+// 0 is the first frame and 1 is the last frame.
+// The position is counted through the existing frames, not the frame numbers.
+//
+// It returns false when the sequence is empty or x is not in [0, 1].
+func (s *Seq) AtFraction(x float64) (int, bool) {
+	if s.frames.len() == 0 || x < 0 || x > 1 {
+		return 0, false
+	}
+	frames := s.sortedFrames()
+	i := int(math.Round(x * float64(len(frames)-1)))
+	return frames[i], true
+}
+
+// Union returns a new sequence that has frames of s or other.
+func (s *Seq) Union(other *Seq) *Seq {
+	u := NewSeq()
+	u.allowNegative = s.allowNegative || other.allowNegative
+	u.frames = s.frames.union(&other.frames)
+	return u
+}
+
+// Intersect returns a new sequence that has frames of both s and other.
+func (s *Seq) Intersect(other *Seq) *Seq {
+	i := NewSeq()
+	i.allowNegative = s.allowNegative
+	i.frames = s.frames.intersect(&other.frames)
+	return i
+}
 
-	rngs := []*Range{}
-	r := NewRange(frames[0])
-	rngs = append(rngs, r)
-	for _, f := range frames[1:] {
-		ok := r.Extend(f)
-		if !ok {
-			r = NewRange(f)
-			rngs = append(rngs, r)
+// Subtract returns a new sequence that has frames of s but not of other.
+func (s *Seq) Subtract(other *Seq) *Seq {
+	d := NewSeq()
+	d.allowNegative = s.allowNegative
+	d.frames = s.frames.subtract(&other.frames)
+	return d
+}
+
+// SplitAt splits a sequence into multiple sequences by cut points.
+//
+// Each cut is the first frame of a new sequence,
+// so it always returns len(cuts)+1 sequences.
+// A returned sequence could be empty if no frame falls in it's part.
+// Each part keeps the sequence's settings and it's frames' per-frame data.
+func SplitAt(s *Seq, cuts []int) []*Seq {
+	cs := append([]int{}, cuts...)
+	sort.Ints(cs)
+	seqs := make([]*Seq, len(cs)+1)
+	lo := math.MinInt
+	for i := range seqs {
+		if i == len(cs) {
+			seqs[i] = s.part(lo, math.MaxInt)
+			break
+		}
+		if cs[i] == math.MinInt {
+			seqs[i] = s.part(1, 0)
+		} else {
+			seqs[i] = s.part(lo, cs[i]-1)
+		}
+		lo = cs[i]
+	}
+	return seqs
+}
+
+// part returns a copy of the sequence with frames between lo and hi,
+// and their per-frame data. It is empty when lo is bigger than hi.
+func (s *Seq) part(lo, hi int) *Seq {
+	c := NewSeq()
+	c.gapTolerance = s.gapTolerance
+	c.allowNegative = s.allowNegative
+	if lo > hi {
+		return c
+	}
+	c.frames = s.frames.clip(lo, hi)
+	in := func(f int) bool { return f >= lo && f <= hi }
+	for f, n := range s.attempts {
+		if in(f) {
+			if c.attempts == nil {
+				c.attempts = make(map[int]int)
+			}
+			c.attempts[f] = n
+		}
+	}
+	for f := range s.excluded {
+		if in(f) {
+			if c.excluded == nil {
+				c.excluded = make(map[int]struct{})
+			}
+			c.excluded[f] = struct{}{}
+		}
+	}
+	for f, st := range s.statuses {
+		if in(f) {
+			c.SetStatus(f, st)
+		}
+	}
+	for f, vs := range s.views {
+		if in(f) {
+			if c.views == nil {
+				c.views = make(map[int]map[string]struct{})
+			}
+			c.views[f] = make(map[string]struct{}, len(vs))
+			for v := range vs {
+				c.views[f][v] = struct{}{}
+			}
 		}
 	}
-	return rngs
+	return c
 }
 
 // String expresses a sequence using ranges.
 func (s *Seq) String() string {
-	str := ""
-	rngs := s.Ranges()
-	for _, r := range rngs {
-		if str != "" {
-			str += " "
+	return rangesString(s.Ranges())
+}
+
+// exactString expresses a sequence using ranges those have the exact frames,
+// regardless of the gap tolerance. ParseSeq parses it back.
+func (s *Seq) exactString() string {
+	return rangesString(s.frames.steppedRanges())
+}
+
+// rangesString joins ranges with spaces.
+func rangesString(rngs []*Range) string {
+	var b strings.Builder
+	for i, r := range rngs {
+		if i != 0 {
+			b.WriteByte(' ')
 		}
-		str += r.String()
+		b.WriteString(r.String())
 	}
-	return str
+	return b.String()
+}
+
+// ParseSeq parses a sequence string made by Seq.String,
+// like "1-4 98-100". Ranges could also be separated by commas.
+//
+// Overlapping ranges are merged. When it has negative frames,
+// the sequence allows negative frames.
+func ParseSeq(str string) (*Seq, error) {
+	s := NewSeq()
+	fields := strings.FieldsFunc(str, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for _, field := range fields {
+		r, err := ParseRange(field)
+		if err != nil {
+			return nil, err
+		}
+		s.frames.addRange(r.Min, r.Max, r.step())
+		if r.Min < 0 {
+			s.allowNegative = true
+		}
+	}
+	return s, nil
 }
 
-// Range is a contiguous frame range,
-// which includes Max frame.
+// Range is a frame range, which includes Max frame.
+//
+// Step is the increment between the frames, so a range rendered on twos
+// has Step 2. Zero Step is treated as 1, which makes a contiguous range.
 type Range struct {
-	Min int
-	Max int
+	Min  int
+	Max  int
+	Step int
 }
 
 // NewRange creates a new range.
@@ -214,11 +1689,25 @@ func NewRange(f int) *Range {
 	}
 }
 
-// Extend extends a range by one, only if,
-// input frame is bigger than current max frame by 1.
+// step returns the increment between it's frames.
+func (r *Range) step() int {
+	if r.Step < 1 {
+		return 1
+	}
+	return r.Step
+}
+
+// Contains reports whether the range has the frame.
+// A stepped range only has the frames on it's steps.
+func (r *Range) Contains(f int) bool {
+	return f >= r.Min && f <= r.Max && (f-r.Min)%r.step() == 0
+}
+
+// Extend extends a range by a step, only if,
+// input frame is bigger than current max frame by the step.
 // When it extends, it returns true, or it returns false.
 func (r *Range) Extend(f int) bool {
-	if f != r.Max+1 {
+	if f != r.Max+r.step() {
 		return false
 	}
 	r.Max = f
@@ -227,9 +1716,90 @@ func (r *Range) Extend(f int) bool {
 
 // String expresses the range with dash. Like "1-10".
 // But if the min and max are same, it will just show one. Like "5".
+// A stepped range shows it's step after x. Like "1-9x2".
+//
+// When the range has a negative frame, it uses ".." instead,
+// to not be confused with minus signs. Like "-5..-1".
 func (r *Range) String() string {
 	if r.Min == r.Max {
 		return fmt.Sprintf("%d", r.Min)
 	}
-	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+	sep := "-"
+	if r.Min < 0 {
+		sep = ".."
+	}
+	if r.step() != 1 {
+		return fmt.Sprintf("%d%s%dx%d", r.Min, sep, r.Max, r.step())
+	}
+	return fmt.Sprintf("%d%s%d", r.Min, sep, r.Max)
+}
+
+// ParseRange parses a range string made by Range.String,
+// like "1-10", "1-9x2", "5", "-5..-1" or "-5".
+//
+// The max of a stepped range is snapped down to it's last frame,
+// so "1-10x2" is parsed as "1-9x2".
+func ParseRange(str string) (*Range, error) {
+	rngStr, stepStr, stepped := strings.Cut(str, "x")
+	var minStr, maxStr string
+	isRange, signed := false, false
+	switch {
+	case strings.Contains(rngStr, ".."):
+		minStr, maxStr, isRange = strings.Cut(rngStr, "..")
+		signed = true
+	case strings.HasPrefix(rngStr, "-"):
+		minStr, maxStr = rngStr, rngStr
+		signed = true
+	default:
+		minStr, maxStr, isRange = strings.Cut(rngStr, "-")
+		if !isRange {
+			maxStr = minStr
+		}
+	}
+	step := 0
+	if stepped {
+		var err error
+		step, err = parseFrame(stepStr, false)
+		if err != nil || step < 1 || !isRange {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRange, str)
+		}
+		if step == 1 {
+			step = 0
+		}
+	}
+	min, err := parseFrame(minStr, signed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRange, str)
+	}
+	max, err := parseFrame(maxStr, signed)
+	if err != nil || max < min {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRange, str)
+	}
+	if step != 0 {
+		// uint keeps the distance of far apart frames from overflow.
+		d := uint(max) - uint(min)
+		max = min + int(d/uint(step)*uint(step))
+		if max == min {
+			step = 0
+		}
+	}
+	return &Range{Min: min, Max: max, Step: step}, nil
+}
+
+// parseFrame parses a frame number that only has digits.
+// When signed is true, it could have a leading minus sign.
+func parseFrame(str string, signed bool) (int, error) {
+	digits := str
+	if signed {
+		digits = strings.TrimPrefix(str, "-")
+	}
+	if digits == "" {
+		return 0, strconv.ErrSyntax
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, strconv.ErrSyntax
+		}
+	}
+	return strconv.Atoi(str)
 }
@@ -1,7 +1,15 @@
 package sequence
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -66,23 +74,77 @@ func TestFormatting(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		gotSharp := FmtSharp(c.pre, c.digits, c.post)
+		gotSharp := FmtSharp.Format(c.pre, c.digits, c.post)
 		if gotSharp != c.wantSharp {
 			t.Fatalf("FmtSharp - got: %v, want: %v", gotSharp, c.wantSharp)
 		}
 
-		gotDollarF := FmtDollarF(c.pre, c.digits, c.post)
+		gotDollarF := FmtDollarF.Format(c.pre, c.digits, c.post)
 		if gotDollarF != c.wantDollarF {
 			t.Fatalf("FmtDollarF - got: %v, want: %v", gotDollarF, c.wantDollarF)
 		}
 
-		gotPercentD := FmtPercentD(c.pre, c.digits, c.post)
+		gotPercentD := FmtPercentD.Format(c.pre, c.digits, c.post)
 		if gotPercentD != c.wantPercentD {
 			t.Fatalf("FmtPercentD - got: %v, want: %v", gotPercentD, c.wantPercentD)
 		}
 	}
 }
 
+func TestFormatterParse(t *testing.T) {
+	want := Key{Pre: "/a/v01/img.", Pad: 4, Post: ".exr"}
+	for _, f := range []Formatter{FmtSharp, FmtDollarF, FmtPercentD, SlashFormatter(FmtSharp)} {
+		name := want.Format(f)
+		got, err := f.Parse(name)
+		if err != nil {
+			t.Fatalf("%q: got error: %v", name, err)
+		}
+		if got != want {
+			t.Fatalf("%q: got: %v, want: %v", name, got, want)
+		}
+	}
+
+	cases := []struct {
+		f    Formatter
+		key  Key
+		name string
+	}{
+		{f: FmtAt, key: Key{Pre: "/a/img.", Pad: 4, Post: ".exr"}, name: "/a/img.@@@@.exr"},
+		{f: FmtDollarFPlain, key: Key{Pre: "/a/img.", Pad: 0, Post: ".exr"}, name: "/a/img.$F.exr"},
+		{f: FmtFFmpeg, key: Key{Pre: "/a/100%/img.", Pad: 4, Post: ".png"}, name: "/a/100%%/img.%04d.png"},
+		{f: FmtFFmpeg, key: Key{Pre: "/a/%d/img.", Pad: 3, Post: ".png"}, name: "/a/%%d/img.%03d.png"},
+	}
+	for _, c := range cases {
+		if got := c.key.Format(c.f); got != c.name {
+			t.Fatalf("got: %q, want: %q", got, c.name)
+		}
+		got, err := c.f.Parse(c.name)
+		if err != nil {
+			t.Fatalf("%q: got error: %v", c.name, err)
+		}
+		if got != c.key {
+			t.Fatalf("%q: got: %v, want: %v", c.name, got, c.key)
+		}
+	}
+	if name := (Key{Pre: "img.", Pad: 4, Post: ".exr"}).Format(FmtDollarFPlain); name != "img.$F.exr" {
+		t.Fatalf("got: %q, want: %q", name, "img.$F.exr")
+	}
+	for _, name := range []string{"/a/img.$F4.exr", "/a/img.exr"} {
+		if _, err := FmtDollarFPlain.Parse(name); !errors.Is(err, ErrNoFrameToken) {
+			t.Fatalf("%q: got err: %v, want: %v", name, err, ErrNoFrameToken)
+		}
+	}
+	if _, err := FmtFFmpeg.Parse("/a/100%%.png"); !errors.Is(err, ErrNoFrameToken) {
+		t.Fatalf("got err: %v, want: %v", err, ErrNoFrameToken)
+	}
+	if _, err := FmtSharp.Parse("/a/img.%04d.exr"); !errors.Is(err, ErrNoFrameToken) {
+		t.Fatalf("got err: %v, want: %v", err, ErrNoFrameToken)
+	}
+	if got, err := FmtUDIM.Parse("/tex/wood.<UDIM>.png"); err != nil || got != (Key{Pre: "/tex/wood.", Pad: 4, Post: ".png"}) {
+		t.Fatalf("got: %v, %v", got, err)
+	}
+}
+
 func TestDefaultUsecase(t *testing.T) {
 	cases := []struct {
 		files []string
@@ -117,3 +179,1004 @@ func TestDefaultUsecase(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitAt(t *testing.T) {
+	cases := []struct {
+		frames []int
+		cuts   []int
+		want   []string
+	}{
+		{
+			frames: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			cuts:   []int{4, 8},
+			want:   []string{"1-3", "4-7", "8-10"},
+		},
+		{
+			frames: []int{1, 2, 3, 10, 11},
+			cuts:   []int{10, 5},
+			want:   []string{"1-3", "", "10-11"},
+		},
+		{
+			frames: []int{1, 2, 3},
+			cuts:   []int{},
+			want:   []string{"1-3"},
+		},
+	}
+	for _, c := range cases {
+		s := NewSeq()
+		for _, f := range c.frames {
+			s.AddFrame(f)
+		}
+		got := []string{}
+		for _, sub := range SplitAt(s, c.cuts) {
+			got = append(got, sub.String())
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("got: %q, want: %q", got, c.want)
+		}
+	}
+
+	// Parts keep the settings and per-frame data of their frames.
+	s := NewSeq()
+	s.SetAllowNegative(true)
+	s.SetGapTolerance(1)
+	for _, f := range []int{-2, -1, 1, 3, 4} {
+		s.AddFrame(f)
+	}
+	s.SetStatus(-1, StatusFailed)
+	s.SetStatus(3, StatusDone)
+	parts := SplitAt(s, []int{0})
+	if !parts[0].allowNegative || parts[0].gapTolerance != 1 {
+		t.Fatalf("settings are not kept")
+	}
+	if got, want := parts[1].String(), "1-4"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if st, _ := parts[0].Status(-1); st != StatusFailed {
+		t.Fatalf("got status: %q, want: %q", st, StatusFailed)
+	}
+	if st, _ := parts[1].Status(3); st != StatusDone {
+		t.Fatalf("got status: %q, want: %q", st, StatusDone)
+	}
+	if _, ok := parts[0].Status(3); ok {
+		t.Fatalf("frame 3 status should not be in the first part")
+	}
+}
+
+func TestSampleRandom(t *testing.T) {
+	s := NewSeq()
+	for f := 1; f <= 100; f++ {
+		s.AddFrame(f)
+	}
+	got := s.SampleRandom(5, 42)
+	if len(got) != 5 {
+		t.Fatalf("got %d frames, want 5", len(got))
+	}
+	if !sort.IntsAreSorted(got) {
+		t.Fatalf("frames are not sorted: %v", got)
+	}
+	for _, f := range got {
+		if f < 1 || f > 100 {
+			t.Fatalf("frame %d is not in the sequence", f)
+		}
+	}
+	again := s.SampleRandom(5, 42)
+	if !reflect.DeepEqual(got, again) {
+		t.Fatalf("same seed gave different frames: %v, %v", got, again)
+	}
+	all := s.SampleRandom(200, 42)
+	if len(all) != 100 {
+		t.Fatalf("got %d frames, want 100", len(all))
+	}
+}
+
+func TestAtFraction(t *testing.T) {
+	s := NewSeq()
+	for _, f := range []int{1, 2, 3, 10, 20} {
+		s.AddFrame(f)
+	}
+	cases := []struct {
+		x      float64
+		want   int
+		wantOK bool
+	}{
+		{x: 0, want: 1, wantOK: true},
+		{x: 0.5, want: 3, wantOK: true},
+		{x: 0.75, want: 10, wantOK: true},
+		{x: 1, want: 20, wantOK: true},
+		{x: -0.1, wantOK: false},
+		{x: 1.1, wantOK: false},
+	}
+	for _, c := range cases {
+		got, ok := s.AtFraction(c.x)
+		if ok != c.wantOK || got != c.want {
+			t.Fatalf("AtFraction(%v) - got: %v, %v, want: %v, %v", c.x, got, ok, c.want, c.wantOK)
+		}
+	}
+	if _, ok := NewSeq().AtFraction(0.5); ok {
+		t.Fatalf("empty sequence should not have a frame")
+	}
+}
+
+func TestGapTolerance(t *testing.T) {
+	cases := []struct {
+		frames      []int
+		tolerance   int
+		want        string
+		wantBridged []int
+	}{
+		{
+			frames:      []int{1, 2, 3, 5, 6, 9, 10},
+			tolerance:   0,
+			want:        "1-3 5-6 9-10",
+			wantBridged: []int{},
+		},
+		{
+			frames:      []int{1, 2, 3, 5, 6, 9, 10},
+			tolerance:   1,
+			want:        "1-6 9-10",
+			wantBridged: []int{4},
+		},
+		{
+			frames:      []int{1, 2, 3, 5, 6, 9, 10},
+			tolerance:   2,
+			want:        "1-10",
+			wantBridged: []int{4, 7, 8},
+		},
+	}
+	for _, c := range cases {
+		s := NewSeq()
+		for _, f := range c.frames {
+			s.AddFrame(f)
+		}
+		s.SetGapTolerance(c.tolerance)
+		if got := s.String(); got != c.want {
+			t.Fatalf("tolerance %d - got: %q, want: %q", c.tolerance, got, c.want)
+		}
+		if got := s.BridgedFrames(); !reflect.DeepEqual(got, c.wantBridged) {
+			t.Fatalf("tolerance %d - got bridged: %v, want: %v", c.tolerance, got, c.wantBridged)
+		}
+	}
+}
+
+func TestRekey(t *testing.T) {
+	files := []string{
+		"/a/img.0001.exr",
+		"/a/img.0002.exr",
+		"/a/img.00003.exr",
+	}
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	wantKey := Key{Pre: "/a/img.", Pad: 4, Post: ".exr"}
+	if k, ok := man.Key("/a/img.####.exr"); !ok || k != wantKey {
+		t.Fatalf("got key: %v, want: %v", k, wantKey)
+	}
+
+	if err := man.Rekey(FmtPercentD); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := "/a/img.%04d.exr 1-2\n/a/img.%05d.exr 3"
+	if got := man.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if k, ok := man.Key("/a/img.%04d.exr"); !ok || k != wantKey {
+		t.Fatalf("got key: %v, want: %v", k, wantKey)
+	}
+	if err := man.Add("/a/img.0004.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got := man.Seqs["/a/img.%04d.exr"].String(); got != "1-2 4" {
+		t.Fatalf("got: %q, want: %q", got, "1-2 4")
+	}
+
+	noPad := NewFormatter(
+		func(pre, digits, post string) string {
+			return pre + "#" + post
+		},
+		func(name string) (Key, error) {
+			return Key{}, ErrNoFrameToken
+		},
+	)
+	if err := man.Rekey(noPad); !errors.Is(err, ErrNameCollision) {
+		t.Fatalf("got err: %v, want: %v", err, ErrNameCollision)
+	}
+	if got := man.String(); got != "/a/img.%04d.exr 1-2 4\n/a/img.%05d.exr 3" {
+		t.Fatalf("manager changed after failed rekey: %q", got)
+	}
+}
+
+func TestInDir(t *testing.T) {
+	files := []string{
+		"/show/shots/sh010/comp/img.0001.exr",
+		"/show/shots/sh010/comp/v002/img.0001.exr",
+		"/show/shots/sh010/comp/0001.exr",
+		"/show/shots/sh010/comp_old/img.0001.exr",
+		"/show/shots/sh020/comp/img.0001.exr",
+	}
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	cases := []struct {
+		dir  string
+		want []string
+	}{
+		{
+			dir: "/show/shots/sh010/comp",
+			want: []string{
+				"/show/shots/sh010/comp/####.exr",
+				"/show/shots/sh010/comp/img.####.exr",
+				"/show/shots/sh010/comp/v002/img.####.exr",
+			},
+		},
+		{
+			dir:  "/show/shots/sh020/",
+			want: []string{"/show/shots/sh020/comp/img.####.exr"},
+		},
+		{
+			dir:  "/show/shots/sh030",
+			want: []string{},
+		},
+		{
+			dir:  "/",
+			want: man.SeqNames(),
+		},
+	}
+	for _, c := range cases {
+		got := man.InDir(c.dir)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("InDir(%q) - got: %q, want: %q", c.dir, got, c.want)
+		}
+	}
+
+	rel := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"img.0001.exr", "a/img.0001.exr", "a/b/img.0001.exr", "../c/img.0001.exr"} {
+		if err := rel.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	want := []string{"a/b/img.####.exr", "a/img.####.exr", "img.####.exr"}
+	for _, dir := range []string{".", "./", ""} {
+		if got := rel.InDir(dir); !reflect.DeepEqual(got, want) {
+			t.Fatalf("InDir(%q) - got: %q, want: %q", dir, got, want)
+		}
+	}
+	if got, want := rel.InDir("a"), []string{"a/b/img.####.exr", "a/img.####.exr"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("InDir(%q) - got: %q, want: %q", "a", got, want)
+	}
+}
+
+func TestFind(t *testing.T) {
+	files := []string{
+		"/a/img.0001.exr",
+		"/a/img_mask.0001.exr",
+		"/a/img.0001.png",
+		"/b/img.0001.exr",
+	}
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+
+	got, err := man.Find("/a/img*.exr")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := []string{"/a/img.####.exr", "/a/img_mask.####.exr"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Find - got: %q, want: %q", got, want)
+	}
+	baseCases := []struct {
+		pattern string
+		want    []string
+	}{
+		{pattern: "*.exr", want: []string{"/a/img.####.exr", "/a/img_mask.####.exr", "/b/img.####.exr"}},
+		{pattern: "img_*", want: []string{"/a/img_mask.####.exr"}},
+		{pattern: "/b/*", want: []string{"/b/img.####.exr"}},
+	}
+	for _, c := range baseCases {
+		got, err := man.Find(c.pattern)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("Find(%q) - got: %q, want: %q", c.pattern, got, c.want)
+		}
+	}
+	if _, err := man.Find("/a/[img"); err == nil {
+		t.Fatalf("Find should fail with malformed pattern")
+	}
+
+	got = man.FindRegexp(regexp.MustCompile(`^/[ab]/img\.#+\.exr$`))
+	want = []string{"/a/img.####.exr", "/b/img.####.exr"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FindRegexp - got: %q, want: %q", got, want)
+	}
+}
+
+func TestPage(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"a.1.exr", "b.1.exr", "c.1.exr", "d.1.exr", "e.1.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	pageCases := []struct {
+		offset int
+		limit  int
+		want   []string
+	}{
+		{offset: 0, limit: 2, want: []string{"a.#.exr", "b.#.exr"}},
+		{offset: 4, limit: 2, want: []string{"e.#.exr"}},
+		{offset: 5, limit: 2, want: []string{}},
+		{offset: 1, limit: 0, want: []string{}},
+	}
+	for _, c := range pageCases {
+		got := man.Page(c.offset, c.limit)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("Page(%d, %d) - got: %q, want: %q", c.offset, c.limit, got, c.want)
+		}
+	}
+
+	got := []string{}
+	cursor := ""
+	for {
+		names := man.After(cursor, 2)
+		if len(names) == 0 {
+			break
+		}
+		got = append(got, names...)
+		cursor = names[len(names)-1]
+	}
+	if !reflect.DeepEqual(got, man.SeqNames()) {
+		t.Fatalf("After - got: %q, want: %q", got, man.SeqNames())
+	}
+	if got := man.After("b", 1); !reflect.DeepEqual(got, []string{"b.#.exr"}) {
+		t.Fatalf("After - got: %q, want: %q", got, []string{"b.#.exr"})
+	}
+}
+
+func TestSeqNamesSnapshot(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"c.1.exr", "a.1.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	snap := man.SeqNames()
+	if err := man.Add("b.1.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if want := []string{"a.#.exr", "c.#.exr"}; !reflect.DeepEqual(snap, want) {
+		t.Fatalf("snapshot changed - got: %q, want: %q", snap, want)
+	}
+	if want := []string{"a.#.exr", "b.#.exr", "c.#.exr"}; !reflect.DeepEqual(man.SeqNames(), want) {
+		t.Fatalf("got: %q, want: %q", man.SeqNames(), want)
+	}
+
+	// Modifying a returned names should not affect the manager.
+	names := man.SeqNames()
+	names[0], names[2] = names[2], names[0]
+	if want := []string{"a.#.exr", "b.#.exr", "c.#.exr"}; !reflect.DeepEqual(man.SeqNames(), want) {
+		t.Fatalf("names modified - got: %q, want: %q", man.SeqNames(), want)
+	}
+
+	// Names removed and added again before they are read.
+	for _, f := range []string{"b.1.exr", "d.1.exr", "a.1.exr"} {
+		man.Remove(f)
+		man.Add(f)
+	}
+	man.Remove("a.1.exr")
+	if want := []string{"b.#.exr", "c.#.exr", "d.#.exr"}; !reflect.DeepEqual(man.SeqNames(), want) {
+		t.Fatalf("got: %q, want: %q", man.SeqNames(), want)
+	}
+}
+
+func BenchmarkAddSequences(b *testing.B) {
+	files := make([]string, 100000)
+	for i := range files {
+		files[i] = fmt.Sprintf("/show/shot%d/img.0001.exr", i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		man := NewManager(DefaultSplitter, FmtSharp)
+		for _, f := range files {
+			man.Add(f)
+		}
+		if len(man.SeqNames()) != len(files) {
+			b.Fatalf("got %d names", len(man.SeqNames()))
+		}
+	}
+}
+
+func TestManagerClone(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"a.1.exr", "a.2.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	c := man.Clone()
+	for _, f := range []string{"a.3.exr", "b.1.exr"} {
+		if err := c.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if err := c.Rekey(FmtPercentD); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := man.String(), "a.#.exr 1-2"; got != want {
+		t.Fatalf("original changed - got: %q, want: %q", got, want)
+	}
+	if got, want := c.String(), "a.%01d.exr 1-3\nb.%01d.exr 1"; got != want {
+		t.Fatalf("clone - got: %q, want: %q", got, want)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"a.1.exr", "a.2.exr", "b.1.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if err := man.Remove("a.1.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if err := man.Remove("b.1.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if err := man.Remove("b.1.exr"); !errors.Is(err, ErrFrameNotFound) {
+		t.Fatalf("got err: %v, want: %v", err, ErrFrameNotFound)
+	}
+	if got, want := man.String(), "a.#.exr 2"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if _, ok := man.Key("b.#.exr"); ok {
+		t.Fatalf("empty sequence should be removed")
+	}
+}
+
+func TestStrictSplitter(t *testing.T) {
+	strict := DefaultSplitter.WithStrict()
+	cases := []struct {
+		fname     string
+		want      []string
+		wantCands []Candidate
+	}{
+		{
+			fname: "/a/b2/img.0001.exr",
+			want:  []string{"/a/b2/img.", "0001", ".exr"},
+		},
+		{
+			fname: "S01C002_img.0001.exr",
+			wantCands: []Candidate{
+				{Pre: "S", Digits: "01", Post: "C002_img.0001.exr"},
+				{Pre: "S01C", Digits: "002", Post: "_img.0001.exr"},
+				{Pre: "S01C002_img.", Digits: "0001", Post: ".exr"},
+			},
+		},
+		{
+			fname: "comp_v001.exr",
+			wantCands: []Candidate{
+				{Pre: "comp_v", Digits: "001", Post: ".exr"},
+			},
+		},
+	}
+	for _, c := range cases {
+		pre, digits, post, err := strict.Split(c.fname)
+		if c.wantCands == nil {
+			if err != nil {
+				t.Fatalf("%q: got err: %v", c.fname, err)
+			}
+			if got := []string{pre, digits, post}; !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got: %q, want: %q", got, c.want)
+			}
+			continue
+		}
+		var amb *AmbiguousNameError
+		if !errors.As(err, &amb) {
+			t.Fatalf("%q: got err: %v, want AmbiguousNameError", c.fname, err)
+		}
+		if !reflect.DeepEqual(amb.Candidates, c.wantCands) {
+			t.Fatalf("got: %q, want: %q", amb.Candidates, c.wantCands)
+		}
+	}
+	if _, _, _, err := DefaultSplitter.Split("S01C002_img.0001.exr"); err != nil {
+		t.Fatalf("DefaultSplitter should not be strict: %v", err)
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	cases := []struct {
+		fname string
+		want  float64
+	}{
+		{fname: "/a/img.0001.exr", want: 1},
+		{fname: "img0001.exr", want: 0.8},
+		{fname: "img.1.exr", want: 0.8},
+		{fname: "S01C002_img.0001.exr", want: 0.75},
+		{fname: "comp_v001.exr", want: 0.5},
+	}
+	for _, c := range cases {
+		got, err := DefaultSplitter.Confidence(c.fname)
+		if err != nil {
+			t.Fatalf("%q: got err: %v", c.fname, err)
+		}
+		if math.Abs(got-c.want) > 1e-9 {
+			t.Fatalf("%q: got: %v, want: %v", c.fname, got, c.want)
+		}
+	}
+	if _, err := DefaultSplitter.Confidence("img.exr"); !errors.Is(err, ErrNotSeqfile) {
+		t.Fatalf("got err: %v, want: %v", err, ErrNotSeqfile)
+	}
+}
+
+func TestNormalizer(t *testing.T) {
+	nfd := "/a/cafe\u0301/img."
+	nfc := "/a/caf\u00e9/img."
+	toNFC := strings.NewReplacer("e\u0301", "\u00e9").Replace
+
+	man := NewManager(DefaultSplitter, FmtSharp)
+	man.SetNormalizer(toNFC)
+	for _, f := range []string{nfd + "0001.exr", nfc + "0002.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if got, want := man.String(), nfc+"####.exr 1-2"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	k, _ := man.Key(nfc + "####.exr")
+	if k.Pre != nfd {
+		t.Fatalf("key should keep the original parts - got: %q, want: %q", k.Pre, nfd)
+	}
+	if err := man.Rekey(FmtPercentD); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := man.SeqNames(), []string{nfc + "%04d.exr"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	// Operations on disk use the original names of each frame.
+	files, err := man.Files(nfc + "%04d.exr")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if want := []string{nfd + "0001.exr", nfc + "0002.exr"}; !reflect.DeepEqual(files, want) {
+		t.Fatalf("got: %q, want: %q", files, want)
+	}
+	renames, err := man.RenumberPlan(nfc+"%04d.exr", 11, 1)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	want := []Rename{
+		{From: nfd + "0001.exr", To: nfd + "0011.exr"},
+		{From: nfc + "0002.exr", To: nfc + "0012.exr"},
+	}
+	if !reflect.DeepEqual(renames, want) {
+		t.Fatalf("got: %q, want: %q", renames, want)
+	}
+	if err := man.Renumber(nfc+"%04d.exr", 11, 1); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	files, _ = man.Clone().Files(nfc + "%04d.exr")
+	if want := []string{nfd + "0011.exr", nfc + "0012.exr"}; !reflect.DeepEqual(files, want) {
+		t.Fatalf("got: %q, want: %q", files, want)
+	}
+	if err := man.Remove(nfc + "0012.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if files, _ = man.Files(nfc + "%04d.exr"); !reflect.DeepEqual(files, []string{nfd + "0011.exr"}) {
+		t.Fatalf("got: %q, want: %q", files, []string{nfd + "0011.exr"})
+	}
+}
+
+func TestSlashFormatter(t *testing.T) {
+	man := NewManager(DefaultSplitter, SlashFormatter(FmtSharp))
+	for _, f := range []string{`C:\renders\img.0001.exr`, "C:/renders/img.0002.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if got, want := man.String(), "C:/renders/img.####.exr 1-2"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	native := NativeFormatter(FmtSharp).Format(`a\b/img.`, "0001", ".exr")
+	if want := filepath.FromSlash("a/b/img.####.exr"); native != want {
+		t.Fatalf("got: %q, want: %q", native, want)
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	s := NewSeq()
+	for i := 0; i < 3; i++ {
+		s.RecordAttempt(10)
+	}
+	s.RecordAttempt(5)
+	if got := s.RecordAttempt(5); got != 2 {
+		t.Fatalf("got: %d, want: 2", got)
+	}
+	s.RecordAttempt(1)
+	if got := s.Attempts(10); got != 3 {
+		t.Fatalf("got: %d, want: 3", got)
+	}
+	if got := s.Attempts(2); got != 0 {
+		t.Fatalf("got: %d, want: 0", got)
+	}
+	if got, want := s.OverAttempted(1), []int{5, 10}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+	c := s.Clone()
+	c.RecordAttempt(1)
+	if s.Attempts(1) != 1 {
+		t.Fatalf("clone should not share attempts")
+	}
+}
+
+func TestExclude(t *testing.T) {
+	s := NewSeq()
+	s.AddFrame(1)
+	bad := NewSeq()
+	bad.AddFrame(1)
+	bad.AddFrame(3)
+	s.Exclude(bad)
+	for f, want := range map[int]bool{1: true, 2: false, 3: true} {
+		if got := s.Excluded(f); got != want {
+			t.Fatalf("Excluded(%d) - got: %v, want: %v", f, got, want)
+		}
+	}
+	if got, want := s.String(), "1"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestParseSeq(t *testing.T) {
+	cases := []struct {
+		str     string
+		want    string
+		wantErr error
+	}{
+		{str: "1-4 98-100", want: "1-4 98-100"},
+		{str: "5", want: "5"},
+		{str: "", want: ""},
+		{str: "1-3,7, 9-10", want: "1-3 7 9-10"},
+		{str: "1-5 3-8", want: "1-8"},
+		{str: "10-1", wantErr: ErrInvalidRange},
+		{str: "1-a", wantErr: ErrInvalidRange},
+		{str: "+5", wantErr: ErrInvalidRange},
+		{str: "1--4", wantErr: ErrInvalidRange},
+	}
+	for _, c := range cases {
+		got, err := ParseSeq(c.str)
+		if !errors.Is(err, c.wantErr) {
+			t.Fatalf("%q: got err: %v, want: %v", c.str, err, c.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if got.String() != c.want {
+			t.Fatalf("%q: got: %q, want: %q", c.str, got, c.want)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	r, err := ParseRange("98-100")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if *r != (Range{Min: 98, Max: 100}) {
+		t.Fatalf("got: %v, want: 98-100", r)
+	}
+	if _, err := ParseRange("1 4"); !errors.Is(err, ErrInvalidRange) {
+		t.Fatalf("got err: %v, want: %v", err, ErrInvalidRange)
+	}
+
+	// The max of a stepped range is it's last frame.
+	cases := []struct {
+		str  string
+		want Range
+	}{
+		{str: "1-10x2", want: Range{Min: 1, Max: 9, Step: 2}},
+		{str: "1-9x2", want: Range{Min: 1, Max: 9, Step: 2}},
+		{str: "-10..-1x4", want: Range{Min: -10, Max: -2, Step: 4}},
+		{str: "5-6x3", want: Range{Min: 5, Max: 5}},
+	}
+	for _, c := range cases {
+		r, err := ParseRange(c.str)
+		if err != nil {
+			t.Fatalf("%q: got error: %v", c.str, err)
+		}
+		if *r != c.want {
+			t.Fatalf("%q: got: %v, want: %v", c.str, *r, c.want)
+		}
+		if !r.Contains(r.Max) {
+			t.Fatalf("%q: max %d is not in the range", c.str, r.Max)
+		}
+	}
+}
+
+func TestSteppedRanges(t *testing.T) {
+	cases := []struct {
+		frames []int
+		want   string
+	}{
+		{frames: []int{1, 3, 5, 7, 9}, want: "1-9x2"},
+		{frames: []int{1, 5, 9, 10, 11, 12}, want: "1-9x4 10-12"},
+		{frames: []int{1, 2, 4, 6, 8}, want: "1-2 4-8x2"},
+		{frames: []int{1, 3}, want: "1 3"},
+		{frames: []int{1, 3, 5, 10}, want: "1-5x2 10"},
+		{frames: []int{1, 2, 3, 5, 7}, want: "1-3 5 7"},
+	}
+	for _, c := range cases {
+		s := NewSeq()
+		for _, f := range c.frames {
+			s.AddFrame(f)
+		}
+		got := s.String()
+		if got != c.want {
+			t.Fatalf("%v - got: %q, want: %q", c.frames, got, c.want)
+		}
+		parsed, err := ParseSeq(got)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		if !reflect.DeepEqual(parsed.sortedFrames(), c.frames) {
+			t.Fatalf("%q - parsed: %v, want: %v", got, parsed.sortedFrames(), c.frames)
+		}
+	}
+
+	r, err := ParseRange("1-10x3")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if *r != (Range{Min: 1, Max: 10, Step: 3}) {
+		t.Fatalf("got: %v, want: 1-10x3", r)
+	}
+	for _, str := range []string{"1-10x0", "5x2", "1-10x"} {
+		if _, err := ParseRange(str); !errors.Is(err, ErrInvalidRange) {
+			t.Fatalf("%q: got err: %v, want: %v", str, err, ErrInvalidRange)
+		}
+	}
+}
+
+func TestGaps(t *testing.T) {
+	s, err := ParseSeq("1-4 7 10-12 20")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	gaps := []string{}
+	for _, r := range s.Gaps() {
+		gaps = append(gaps, r.String())
+	}
+	if want := []string{"5-6", "8-9", "13-19"}; !reflect.DeepEqual(gaps, want) {
+		t.Fatalf("got: %q, want: %q", gaps, want)
+	}
+	if got, want := s.Missing(0, 9), []int{0, 5, 6, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+
+	bad, _ := ParseSeq("5 13-18")
+	s.Exclude(bad)
+	gaps = []string{}
+	for _, r := range s.Gaps() {
+		gaps = append(gaps, r.String())
+	}
+	if want := []string{"6", "8-9", "19"}; !reflect.DeepEqual(gaps, want) {
+		t.Fatalf("with exclusions - got: %q, want: %q", gaps, want)
+	}
+	if got := NewSeq().Gaps(); len(got) != 0 {
+		t.Fatalf("empty sequence should not have gaps: %v", got)
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a, _ := ParseSeq("1-10")
+	b, _ := ParseSeq("5-15")
+	cases := []struct {
+		name string
+		got  *Seq
+		want string
+	}{
+		{name: "Union", got: a.Union(b), want: "1-15"},
+		{name: "Intersect", got: a.Intersect(b), want: "5-10"},
+		{name: "Subtract", got: a.Subtract(b), want: "1-4"},
+		{name: "Subtract reverse", got: b.Subtract(a), want: "11-15"},
+		{name: "Intersect empty", got: a.Intersect(NewSeq()), want: ""},
+	}
+	for _, c := range cases {
+		if c.got.String() != c.want {
+			t.Fatalf("%s - got: %q, want: %q", c.name, c.got, c.want)
+		}
+	}
+	if a.String() != "1-10" || b.String() != "5-15" {
+		t.Fatalf("operands modified: %q, %q", a, b)
+	}
+}
+
+func TestConcurrentAdd(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	var wg sync.WaitGroup
+	for _, dir := range []string{"/a", "/b", "/c", "/d"} {
+		wg.Add(1)
+		go func(dir string) {
+			defer wg.Done()
+			for f := 1; f <= 100; f++ {
+				if err := man.Add(fmt.Sprintf("%s/img.%04d.exr", dir, f)); err != nil {
+					t.Errorf("got error: %v", err)
+				}
+				if err := man.Add(fmt.Sprintf("/shared/%s.%04d.exr", dir[1:], f)); err != nil {
+					t.Errorf("got error: %v", err)
+				}
+				man.SeqNames()
+				man.Seq("/shared/a.####.exr")
+			}
+		}(dir)
+	}
+	wg.Wait()
+	if got := len(man.SeqNames()); got != 8 {
+		t.Fatalf("got %d sequences, want 8", got)
+	}
+	s, ok := man.Seq("/c/img.####.exr")
+	if !ok || s.String() != "1-100" {
+		t.Fatalf("got: %v, want: 1-100", s)
+	}
+}
+
+func TestNegativeFrames(t *testing.T) {
+	files := []string{
+		"/a/img.-0002.exr",
+		"/a/img.-0001.exr",
+		"/a/img.0000.exr",
+		"/a/img.0001.exr",
+		"/a/shot-0003.exr",
+	}
+	man := NewManager(DefaultSplitter.WithNegative(), FmtSharp)
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	want := "/a/img.####.exr -2..1\n/a/shot-####.exr 3"
+	if got := man.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	k, _ := man.Key("/a/img.####.exr")
+	if got, want := k.FileName(-2), "/a/img.-0002.exr"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	if err := NewManager(DefaultSplitter, FmtSharp).Add("/a/img.-0002.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if err := NewSeq().AddFrame(-1); !errors.Is(err, ErrNegativeFrame) {
+		t.Fatalf("got err: %v, want: %v", err, ErrNegativeFrame)
+	}
+
+	cases := []struct {
+		str  string
+		want string
+	}{
+		{str: "-5..-1", want: "-5..-1"},
+		{str: "-5", want: "-5"},
+		{str: "-10..-2x2 1-3", want: "-10..-2x2 1-3"},
+		{str: "-3..3", want: "-3..3"},
+	}
+	for _, c := range cases {
+		s, err := ParseSeq(c.str)
+		if err != nil {
+			t.Fatalf("%q: got error: %v", c.str, err)
+		}
+		if got := s.String(); got != c.want {
+			t.Fatalf("got: %q, want: %q", got, c.want)
+		}
+	}
+	for _, str := range []string{"--5", "-5-1", "1..-5"} {
+		if _, err := ParseRange(str); !errors.Is(err, ErrInvalidRange) {
+			t.Fatalf("%q: got err: %v, want: %v", str, err, ErrInvalidRange)
+		}
+	}
+}
+
+func TestFirstLastLen(t *testing.T) {
+	cases := []struct {
+		frames string
+		first  int
+		last   int
+		len    int
+		ok     bool
+	}{
+		{frames: "1-4 98-100", first: 1, last: 100, len: 7, ok: true},
+		{frames: "-5..-1 3", first: -5, last: 3, len: 6, ok: true},
+		{frames: "", len: 0, ok: false},
+	}
+	for _, c := range cases {
+		s, err := ParseSeq(c.frames)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		first, ok := s.First()
+		if first != c.first || ok != c.ok {
+			t.Fatalf("%q - got first: %d, %v, want: %d, %v", c.frames, first, ok, c.first, c.ok)
+		}
+		last, ok := s.Last()
+		if last != c.last || ok != c.ok {
+			t.Fatalf("%q - got last: %d, %v, want: %d, %v", c.frames, last, ok, c.last, c.ok)
+		}
+		if got := s.Len(); got != c.len {
+			t.Fatalf("%q - got len: %d, want: %d", c.frames, got, c.len)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	s, err := ParseSeq("1-4 98-100")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	for f, want := range map[int]bool{0: false, 1: true, 4: true, 5: false, 99: true, 101: false} {
+		if got := s.Contains(f); got != want {
+			t.Fatalf("Seq.Contains(%d) - got: %v, want: %v", f, got, want)
+		}
+	}
+	r := &Range{Min: 1, Max: 9, Step: 2}
+	for f, want := range map[int]bool{0: false, 1: true, 2: false, 9: true, 10: false, 11: false} {
+		if got := r.Contains(f); got != want {
+			t.Fatalf("Range.Contains(%d) - got: %v, want: %v", f, got, want)
+		}
+	}
+	if !(&Range{Min: -5, Max: -1}).Contains(-3) {
+		t.Fatalf("Range.Contains(-3) should be true")
+	}
+}
+
+func TestSequence(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	files := []string{
+		"/show/sh010/img.0001.exr",
+		"/show/sh010/img.0002.exr",
+		"/depth_v2.001.tif",
+		"plate_01.dpx",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	want := []Sequence{
+		{Name: "/depth_v2.###.tif", Dir: "/", Base: "depth_v2.", Ext: ".tif", Padding: 3},
+		{Name: "/show/sh010/img.####.exr", Dir: "/show/sh010", Base: "img.", Ext: ".exr", Padding: 4},
+		{Name: "plate_##.dpx", Dir: "", Base: "plate_", Ext: ".dpx", Padding: 2},
+	}
+	got := man.Sequences()
+	if len(got) != len(want) {
+		t.Fatalf("got: %d sequences, want: %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Name != w.Name || g.Dir != w.Dir || g.Base != w.Base || g.Ext != w.Ext || g.Padding != w.Padding {
+			t.Fatalf("got: %+v, want: %+v", g, w)
+		}
+	}
+	s, ok := man.Sequence("/show/sh010/img.####.exr")
+	if !ok {
+		t.Fatalf("sequence not found")
+	}
+	if s.Frames.String() != "1-2" || s.Key.Post != ".exr" {
+		t.Fatalf("got: %q, %+v", s.Frames, s.Key)
+	}
+	s.Frames.AddFrame(3)
+	if man.Seqs["/show/sh010/img.####.exr"].Contains(3) {
+		t.Fatalf("manager changed by modifying returned frames")
+	}
+}
@@ -0,0 +1,87 @@
+package sequence
+
+import "sort"
+
+// NaturalLess reports whether a sorts before b in natural order,
+// which compares digit runs by their numbers, so "shot2" comes before "shot10".
+// Number ties, like "v01" and "v1", are sorted lexically.
+func NaturalLess(a, b string) bool {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			ei, ej := digitEnd(a, i), digitEnd(b, j)
+			na, nb := trimZeros(a[i:ei]), trimZeros(b[j:ej])
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			i, j = ei, ej
+			continue
+		}
+		if a[i] != b[j] {
+			return a[i] < b[j]
+		}
+		i++
+		j++
+	}
+	if len(a)-i != len(b)-j {
+		return len(a)-i < len(b)-j
+	}
+	return a < b
+}
+
+// isDigit reports whether c is an ascii digit.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// digitEnd returns the end index of the digit run starts at i.
+func digitEnd(s string, i int) int {
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+// trimZeros trims leading zeros of digits, but keeps the last digit.
+func trimZeros(digits string) string {
+	for len(digits) > 1 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	return digits
+}
+
+// SetLess sets the order of sequence names, which is used by SeqNames,
+// Page, After, String and others those return names in order.
+// Names are sorted again with the order.
+// A nil less sorts names lexically, which is the default.
+//
+// less should be a strict order which returns false for equal names,
+// like NaturalLess.
+func (m *Manager) SetLess(less func(a, b string) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.less = less
+	names := make([]string, 0, len(m.Seqs))
+	for n := range m.Seqs {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool { return m.lessName(names[i], names[j]) })
+	m.setNames(names)
+}
+
+// lessName reports whether name a sorts before b in the manager's order.
+func (m *Manager) lessName(a, b string) bool {
+	if m.less == nil {
+		return a < b
+	}
+	return m.less(a, b)
+}
+
+// searchName returns the index of name in sorted names,
+// or where it would be inserted.
+func (m *Manager) searchName(names []string, name string) int {
+	return sort.Search(len(names), func(i int) bool { return !m.lessName(names[i], name) })
+}
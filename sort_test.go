@@ -0,0 +1,91 @@
+package sequence
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestNaturalLess(t *testing.T) {
+	names := []string{
+		"shot10/img.####.exr",
+		"shot2/img.####.exr",
+		"shot1/img.####.exr",
+		"comp_v10.####.exr",
+		"comp_v01.####.exr",
+		"comp_v1.####.exr",
+		"comp_v9.####.exr",
+		"comp.####.exr",
+	}
+	want := []string{
+		"comp.####.exr",
+		"comp_v01.####.exr",
+		"comp_v1.####.exr",
+		"comp_v9.####.exr",
+		"comp_v10.####.exr",
+		"shot1/img.####.exr",
+		"shot2/img.####.exr",
+		"shot10/img.####.exr",
+	}
+	sort.Slice(names, func(i, j int) bool { return NaturalLess(names[i], names[j]) })
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("got: %q, want: %q", names, want)
+	}
+	for _, n := range want {
+		if NaturalLess(n, n) {
+			t.Fatalf("NaturalLess(%q, %q) should be false", n, n)
+		}
+	}
+}
+
+func TestSetLess(t *testing.T) {
+	man := New()
+	for _, f := range []string{"shot10.0001.exr", "shot2.0001.exr", "shot1.0001.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	want := []string{"shot1.####.exr", "shot10.####.exr", "shot2.####.exr"}
+	if got := man.SeqNames(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	snap := man.SeqNames()
+
+	man.SetLess(NaturalLess)
+	man.Add("shot3.0001.exr")
+	want = []string{"shot1.####.exr", "shot2.####.exr", "shot3.####.exr", "shot10.####.exr"}
+	if got := man.SeqNames(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got := man.After("shot3.####.exr", 10); !reflect.DeepEqual(got, want[3:]) {
+		t.Fatalf("got: %q, want: %q", got, want[3:])
+	}
+	man.Remove("shot2.0001.exr")
+	want = []string{"shot1.####.exr", "shot3.####.exr", "shot10.####.exr"}
+	if got := man.SeqNames(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got := man.Clone().SeqNames(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got := snap[0]; got != "shot1.####.exr" || len(snap) != 3 {
+		t.Fatalf("names snapshot is modified: %q", snap)
+	}
+
+	man = New(WithLess(NaturalLess))
+	man.Add("v10.0001.exr")
+	man.Add("v9.0001.exr")
+	want = []string{"v9.####.exr", "v10.####.exr"}
+	if got := man.SeqNames(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	man = New(WithLess(NaturalLess))
+	for _, f := range []string{"shot10_v1.0001.exr", "shot10_v2.0001.exr", "shot2_v3.0001.exr", "shot2.0001.exr"} {
+		man.Add(f)
+	}
+	want = []string{"shot2.####.exr", "shot2_v3.####.exr", "shot10_v2.####.exr"}
+	if got := man.Latest(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Latest - got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,157 @@
+package sequence
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+var (
+	ErrInvalidStatus = errors.New("invalid status")
+	ErrMissingColumn = errors.New("missing column")
+)
+
+// Status is a render status of a frame.
+type Status string
+
+// Statuses a render manager could report.
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// parseStatus parses a status string.
+func parseStatus(str string) (Status, error) {
+	switch st := Status(str); st {
+	case StatusQueued, StatusRunning, StatusDone, StatusFailed:
+		return st, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidStatus, str)
+}
+
+// SetStatus sets a render status of a frame.
+// The frame does not have to be in the sequence.
+func (s *Seq) SetStatus(f int, st Status) {
+	if s.statuses == nil {
+		s.statuses = make(map[int]Status)
+	}
+	s.statuses[f] = st
+}
+
+// Status returns a render status of a frame.
+// It returns false when the frame does not have a status.
+func (s *Seq) Status(f int) (Status, bool) {
+	st, ok := s.statuses[f]
+	return st, ok
+}
+
+// FramesWithStatus returns frames that have the status, in ascending order.
+func (s *Seq) FramesWithStatus(st Status) []int {
+	frames := []int{}
+	for f, fst := range s.statuses {
+		if fst == st {
+			frames = append(frames, f)
+		}
+	}
+	sort.Ints(frames)
+	return frames
+}
+
+// A FrameStatus is a render status of a frame file.
+type FrameStatus struct {
+	File   string `json:"file"`
+	Status Status `json:"status"`
+}
+
+// ImportStatusCSV reads frame statuses from a CSV dump of a render manager,
+// and sets them to the manager's sequences. See ImportStatus.
+//
+// The first record should be a header that has "file" and "status" columns.
+// Other columns are ignored.
+func (m *Manager) ImportStatusCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return err
+	}
+	fileCol, statusCol := -1, -1
+	for i, h := range header {
+		switch h {
+		case "file":
+			fileCol = i
+		case "status":
+			statusCol = i
+		}
+	}
+	if fileCol < 0 {
+		return fmt.Errorf("%w: %s", ErrMissingColumn, "file")
+	}
+	if statusCol < 0 {
+		return fmt.Errorf("%w: %s", ErrMissingColumn, "status")
+	}
+	fss := []FrameStatus{}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		fss = append(fss, FrameStatus{File: rec[fileCol], Status: Status(rec[statusCol])})
+	}
+	return m.ImportStatus(fss)
+}
+
+// ImportStatusJSON reads frame statuses from a JSON dump of a render manager,
+// and sets them to the manager's sequences. See ImportStatus.
+//
+// The dump should be an array of objects that have "file" and "status".
+func (m *Manager) ImportStatusJSON(r io.Reader) error {
+	fss := []FrameStatus{}
+	if err := json.NewDecoder(r).Decode(&fss); err != nil {
+		return err
+	}
+	return m.ImportStatus(fss)
+}
+
+// ImportStatus sets frame statuses to the manager's sequences.
+//
+// A file whose sequence is not in the manager yet, like a queued frame
+// of a new render, creates the sequence without frames, so it's statuses
+// could be found before the files are written. Files added after join it.
+//
+// Statuses of files those could not be split, or with invalid status
+// are not imported. Then it returns all their errors joined,
+// after importing the others.
+func (m *Manager) ImportStatus(fss []FrameStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	errs := []error{}
+	for _, fs := range fss {
+		st, err := parseStatus(string(fs.Status))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", fs.File, err))
+			continue
+		}
+		name, k, frame, _, err := m.split(fs.File)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", fs.File, err))
+			continue
+		}
+		s, ok := m.Seqs[name]
+		if !ok {
+			s = m.addSeq(name, k)
+			if m.padPolicy != PadStrict {
+				m.groups[m.groupID(k)] = name
+			}
+		}
+		s.SetStatus(frame, st)
+	}
+	return errors.Join(errs...)
+}
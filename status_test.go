@@ -0,0 +1,73 @@
+package sequence
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportStatus(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"/r/img.0001.exr", "/r/img.0002.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+
+	csvDump := `job,file,status
+10,/r/img.0002.exr,done
+10,/r/img.0003.exr,failed
+10,/r/img.0004.exr,running
+11,/r/other.0001.exr,done
+`
+	if err := man.ImportStatusCSV(strings.NewReader(csvDump)); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	jsonDump := `[{"file": "/r/img.0005.exr", "status": "queued"}, {"file": "/r/img.0006.exr", "status": "lost"}]`
+	err := man.ImportStatusJSON(strings.NewReader(jsonDump))
+	if !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("got err: %v, want: %v", err, ErrInvalidStatus)
+	}
+
+	s := man.Seqs["/r/img.####.exr"]
+	cases := []struct {
+		status Status
+		want   []int
+	}{
+		{StatusDone, []int{2}},
+		{StatusFailed, []int{3}},
+		{StatusRunning, []int{4}},
+		{StatusQueued, []int{5}},
+	}
+	for _, c := range cases {
+		if got := s.FramesWithStatus(c.status); !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("%s - got: %v, want: %v", c.status, got, c.want)
+		}
+	}
+	if _, ok := s.Status(1); ok {
+		t.Fatalf("frame 1 should not have a status")
+	}
+
+	// A sequence not on disk yet is created without frames.
+	other, ok := man.Seq("/r/other.####.exr")
+	if !ok {
+		t.Fatalf("sequence of an unmatched status should be created")
+	}
+	if st, _ := other.Status(1); st != StatusDone || other.Len() != 0 {
+		t.Fatalf("got status: %q with %d frames, want: %q with 0 frames", st, other.Len(), StatusDone)
+	}
+	if err := man.Add("/r/other.0001.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if other, _ = man.Seq("/r/other.####.exr"); other.String() != "1" {
+		t.Fatalf("got: %q, want: %q", other, "1")
+	}
+	if st, _ := other.Status(1); st != StatusDone {
+		t.Fatalf("status lost after adding the file - got: %q", st)
+	}
+
+	if err := man.ImportStatusCSV(strings.NewReader("file,state\n")); !errors.Is(err, ErrMissingColumn) {
+		t.Fatalf("got err: %v, want: %v", err, ErrMissingColumn)
+	}
+}
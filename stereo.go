@@ -0,0 +1,136 @@
+package sequence
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultViews are common stereo view tokens, for SetViews.
+var DefaultViews = []string{"left", "right", "L", "R"}
+
+// SetViews makes the manager collapse stereo files, like "img.left.0001.exr"
+// and "img.right.0001.exr", into one sequence named with "%V" token,
+// like "img.%V.####.exr". Use Seq.Views to find views of a frame.
+//
+// A view token should be right before the frame digits,
+// and delimited by '.' or '_', like "_L." or ".right.".
+// Nil views disables the detection.
+//
+// It should be set before adding files.
+func (m *Manager) SetViews(views []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.views = views
+}
+
+// splitView finds a view token at the end of pre,
+// and returns pre with "%V" instead of the token, and the view.
+// It returns pre as is and an empty view, when it does not have one.
+func (m *Manager) splitView(pre string) (string, string) {
+	if len(pre) == 0 || !strings.ContainsAny(pre[len(pre)-1:], "._") {
+		return pre, ""
+	}
+	p := pre[:len(pre)-1]
+	for _, v := range m.views {
+		if !strings.HasSuffix(p, v) {
+			continue
+		}
+		q := p[:len(p)-len(v)]
+		if q == "" || !strings.ContainsAny(q[len(q)-1:], "._") {
+			continue
+		}
+		return q + "%V" + pre[len(pre)-1:], v
+	}
+	return pre, ""
+}
+
+// hasFile reports whether the manager has a frame of a sequence,
+// in the view if it is not empty. The caller should hold the lock.
+func (m *Manager) hasFile(name string, frame int, view string) bool {
+	s, ok := m.Seqs[name]
+	if !ok {
+		return false
+	}
+	if view != "" {
+		_, ok = s.views[frame][view]
+		return ok
+	}
+	return s.frames.has(frame)
+}
+
+// addView adds a frame of a view to a sequence.
+// The frame could already be in the sequence with other views.
+// The caller should hold the lock.
+func (m *Manager) addView(name string, k Key, frame int, view string) error {
+	if s, ok := m.Seqs[name]; ok {
+		if vs, ok := s.views[frame]; ok {
+			if _, ok := vs[view]; ok {
+				return ErrFrameExists
+			}
+			vs[view] = struct{}{}
+			return nil
+		}
+	}
+	if err := m.add(name, k, frame); err != nil {
+		return err
+	}
+	s := m.Seqs[name]
+	if s.views == nil {
+		s.views = make(map[int]map[string]struct{})
+	}
+	s.views[frame] = map[string]struct{}{view: {}}
+	return nil
+}
+
+// removeView removes a view of a frame from a sequence,
+// and removes the frame when it does not have views anymore.
+// The caller should hold the lock.
+func (m *Manager) removeView(name string, frame int, view string) error {
+	s, ok := m.Seqs[name]
+	if !ok {
+		return ErrFrameNotFound
+	}
+	vs := s.views[frame]
+	if _, ok := vs[view]; !ok {
+		return ErrFrameNotFound
+	}
+	delete(vs, view)
+	if len(vs) != 0 {
+		return nil
+	}
+	return m.remove(name, frame)
+}
+
+// Views returns stereo views of a frame in ascending order.
+// It returns nil when the frame does not have a view.
+func (s *Seq) Views(f int) []string {
+	vs, ok := s.views[f]
+	if !ok {
+		return nil
+	}
+	views := make([]string, 0, len(vs))
+	for v := range vs {
+		views = append(views, v)
+	}
+	sort.Strings(views)
+	return views
+}
+
+// FramesWithView returns frames that have the view, in ascending order.
+func (s *Seq) FramesWithView(view string) []int {
+	frames := []int{}
+	for f, vs := range s.views {
+		if _, ok := vs[view]; ok {
+			frames = append(frames, f)
+		}
+	}
+	sort.Ints(frames)
+	return frames
+}
+
+// ViewFileName returns the file name of a frame in a view,
+// by replacing "%V" token of the key.
+func (k Key) ViewFileName(view string, f int) string {
+	k.Pre = strings.Replace(k.Pre, "%V", view, 1)
+	return k.FileName(f)
+}
@@ -0,0 +1,108 @@
+package sequence
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStereo(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	man.SetViews(DefaultViews)
+	files := []string{
+		"/a/img.left.0001.exr",
+		"/a/img.right.0001.exr",
+		"/a/img.left.0002.exr",
+		"/a/img.right.0003.exr",
+		"/a/plate_L.0001.exr",
+		"/a/plate_R.0001.exr",
+		"/a/balL.0001.exr",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	want := "/a/balL.####.exr 1\n/a/img.%V.####.exr 1-3\n/a/plate_%V.####.exr 1"
+	if got := man.String(); got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	s, _ := man.Seq("/a/img.%V.####.exr")
+	if got, want := s.Views(1), []string{"left", "right"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got, want := s.FramesWithView("right"), []int{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+	if err := man.Add("/a/img.left.0001.exr"); err == nil {
+		t.Fatalf("adding a view twice should fail")
+	}
+
+	if err := man.Remove("/a/img.right.0001.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if err := man.Remove("/a/img.right.0001.exr"); err == nil {
+		t.Fatalf("removing a view twice should fail")
+	}
+	if err := man.Remove("/a/img.left.0001.exr"); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	s, _ = man.Seq("/a/img.%V.####.exr")
+	if got, want := s.String(), "2-3"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	tx := man.Begin()
+	tx.Add("/a/img.right.0002.exr")
+	tx.Add("/a/img.right.0002.exr")
+	if err := tx.Commit(); err == nil {
+		t.Fatalf("adding a view twice should fail")
+	}
+	tx = man.Begin()
+	tx.Add("/a/img.right.0002.exr")
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	s, _ = man.Seq("/a/img.%V.####.exr")
+	if got, want := s.Views(2), []string{"left", "right"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	k, _ := man.Key("/a/plate_%V.####.exr")
+	if got, want := k.ViewFileName("R", 1), "/a/plate_R.0001.exr"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestStereoPadPolicy(t *testing.T) {
+	files := []string{
+		"/a/img.left.99.exr",
+		"/a/img.right.99.exr",
+		"/a/img.left.0100.exr",
+	}
+	man := New(WithViews(DefaultViews), WithPadPolicy(PadWidest))
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if got, want := man.String(), "/a/img.%V.####.exr 99-100"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	got, _ := man.Files("/a/img.%V.####.exr")
+	if want := []string{"/a/img.left.99.exr", "/a/img.right.99.exr", "/a/img.left.0100.exr"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if err := man.Add("/a/img.left.0099.exr"); err == nil {
+		t.Fatalf("adding a view twice should fail")
+	}
+
+	// Transactions agree with Add.
+	tx := New(WithViews(DefaultViews), WithPadPolicy(PadWidest)).Begin()
+	for _, f := range files {
+		tx.Add(f)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+}
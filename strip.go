@@ -0,0 +1,53 @@
+package sequence
+
+import "strings"
+
+// Strip renders presence of the expected frames as a strip of block characters,
+// like "█████░░██▒", for terminal dashboards.
+//
+// Each character covers one or more frames, to fit the strip in width.
+// '█' means all of it's frames exist, '░' means none of them,
+// and '▒' means some of them. Excluded frames are not counted as missing.
+// A width of 0 or less gives a character per frame.
+func (s *Seq) Strip(expected Range, width int) string {
+	return s.strip(expected, width, [3]rune{'█', '▒', '░'})
+}
+
+// StripASCII is like Strip, but uses '#', '+' and '.'
+// for terminals that could not draw block characters.
+func (s *Seq) StripASCII(expected Range, width int) string {
+	return s.strip(expected, width, [3]rune{'#', '+', '.'})
+}
+
+// strip renders the strip with full, partial and empty characters.
+func (s *Seq) strip(expected Range, width int, chars [3]rune) string {
+	if expected.Max < expected.Min {
+		return ""
+	}
+	step := expected.step()
+	n := (expected.Max-expected.Min)/step + 1
+	cells := n
+	if width > 0 && width < n {
+		cells = width
+	}
+	var b strings.Builder
+	for i := 0; i < cells; i++ {
+		from, to := i*n/cells, (i+1)*n/cells
+		have := 0
+		for j := from; j < to; j++ {
+			f := expected.Min + j*step
+			if s.frames.has(f) || s.Excluded(f) {
+				have++
+			}
+		}
+		switch have {
+		case to - from:
+			b.WriteRune(chars[0])
+		case 0:
+			b.WriteRune(chars[2])
+		default:
+			b.WriteRune(chars[1])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package sequence
+
+import "testing"
+
+func TestStrip(t *testing.T) {
+	s, err := ParseSeq("1-5 8-9")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	cases := []struct {
+		expected Range
+		width    int
+		want     string
+		ascii    string
+	}{
+		{expected: Range{Min: 1, Max: 10}, width: 0, want: "█████░░██░", ascii: "#####..##."},
+		{expected: Range{Min: 1, Max: 10}, width: 20, want: "█████░░██░", ascii: "#####..##."},
+		{expected: Range{Min: 1, Max: 10}, width: 5, want: "██▒▒▒", ascii: "##+++"},
+		{expected: Range{Min: 1, Max: 9, Step: 2}, width: 0, want: "███░█", ascii: "###.#"},
+		{expected: Range{Min: 11, Max: 20}, width: 2, want: "░░", ascii: ".."},
+		{expected: Range{Min: 2, Max: 1}, width: 2, want: "", ascii: ""},
+	}
+	for _, c := range cases {
+		if got := s.Strip(c.expected, c.width); got != c.want {
+			t.Fatalf("got: %q, want: %q", got, c.want)
+		}
+		if got := s.StripASCII(c.expected, c.width); got != c.ascii {
+			t.Fatalf("got: %q, want: %q", got, c.ascii)
+		}
+	}
+
+	bad, err := ParseSeq("6-7")
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	s.Exclude(bad)
+	if got, want := s.Strip(Range{Min: 1, Max: 10}, 0), "█████████░"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownToken  = errors.New("unknown template token")
+	ErrUnclosedToken = errors.New("unclosed template token")
+)
+
+// ExpandTemplate expands tokens like "{shot}" in a path template
+// with values from the token map.
+//
+// Frame tokens such as "####" or "%04d" are not touched,
+// so the result could be used as a sequence name.
+// It returns ErrUnknownToken when a token is not in the map.
+func ExpandTemplate(tmpl string, tokens map[string]string) (string, error) {
+	var b strings.Builder
+	for {
+		i := strings.Index(tmpl, "{")
+		if i < 0 {
+			b.WriteString(tmpl)
+			return b.String(), nil
+		}
+		j := strings.Index(tmpl[i:], "}")
+		if j < 0 {
+			return "", ErrUnclosedToken
+		}
+		j += i
+		key := tmpl[i+1 : j]
+		v, ok := tokens[key]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrUnknownToken, key)
+		}
+		b.WriteString(tmpl[:i])
+		b.WriteString(v)
+		tmpl = tmpl[j+1:]
+	}
+}
@@ -0,0 +1,45 @@
+package sequence
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	tokens := map[string]string{
+		"project": "proj",
+		"shot":    "sh010",
+		"version": "003",
+	}
+	cases := []struct {
+		tmpl    string
+		want    string
+		wantErr error
+	}{
+		{
+			tmpl: "{project}/{shot}/comp/{shot}_comp_v{version}.####.exr",
+			want: "proj/sh010/comp/sh010_comp_v003.####.exr",
+		},
+		{
+			tmpl: "img.%04d.exr",
+			want: "img.%04d.exr",
+		},
+		{
+			tmpl:    "{project}/{task}/img.####.exr",
+			wantErr: ErrUnknownToken,
+		},
+		{
+			tmpl:    "{project/img.####.exr",
+			wantErr: ErrUnclosedToken,
+		},
+	}
+	for _, c := range cases {
+		got, err := ExpandTemplate(c.tmpl, tokens)
+		if !errors.Is(err, c.wantErr) {
+			t.Fatalf("%q: got err: %v, want: %v", c.tmpl, err, c.wantErr)
+		}
+		if got != c.want {
+			t.Fatalf("got: %q, want: %q", got, c.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidTimecode = errors.New("invalid timecode")
+
+// WithTimecode returns a copy of the splitter, that interprets the digits
+// as HHMMSSFF timecode at fps, like "cam_01000012.dng" of a capture system.
+// The frame is counted from 00:00:00:00, so the files group and range
+// like frame numbers. Drop frame timecode is not supported.
+//
+// A manager using the splitter returns ErrInvalidTimecode,
+// when the digits are not a valid timecode.
+func (s *Splitter) WithTimecode(fps int) *Splitter {
+	c := *s
+	c.fps = fps
+	return &c
+}
+
+// parseTimecode parses HHMMSSFF digits to a frame at fps.
+func parseTimecode(digits string, fps int) (int, error) {
+	if len(digits) != 8 || fps <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimecode, digits)
+	}
+	parts := [4]int{}
+	for i := range parts {
+		n, err := parseFrame(digits[i*2:i*2+2], false)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidTimecode, digits)
+		}
+		parts[i] = n
+	}
+	hh, mm, ss, ff := parts[0], parts[1], parts[2], parts[3]
+	if mm >= 60 || ss >= 60 || ff >= fps {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimecode, digits)
+	}
+	return ((hh*60+mm)*60+ss)*fps + ff, nil
+}
+
+// formatTimecode formats a frame to HHMMSSFF digits at fps.
+func formatTimecode(f, fps int) string {
+	ff := f % fps
+	s := f / fps
+	return fmt.Sprintf("%02d%02d%02d%02d", s/3600, s/60%60, s%60, ff)
+}
@@ -0,0 +1,64 @@
+package sequence
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTimecode(t *testing.T) {
+	man := NewManager(DefaultSplitter.WithTimecode(24), FmtSharp)
+	files := []string{
+		"/cap/cam_01000022.dng",
+		"/cap/cam_01000023.dng",
+		"/cap/cam_01000100.dng",
+		"/cap/cam_01000101.dng",
+		"/cap/cam_01000103.dng",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if got, want := man.String(), "/cap/cam_########.dng 86422-86425 86427"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	k, _ := man.Key("/cap/cam_########.dng")
+	if got, want := k.FileName(86424), "/cap/cam_01000100.dng"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+
+	var b strings.Builder
+	if err := man.WriteListing(&b); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	listing := `"/cap/cam_" 8@24 ".dng" 86422-86425 86427` + "\n"
+	if got := b.String(); got != listing {
+		t.Fatalf("got: %q, want: %q", got, listing)
+	}
+	read := NewManager(DefaultSplitter, FmtSharp)
+	if err := read.ReadListing(strings.NewReader(listing)); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if k, _ := read.Key("/cap/cam_########.dng"); k.FPS != 24 {
+		t.Fatalf("got fps: %d, want: 24", k.FPS)
+	}
+	data, err := json.Marshal(man)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	dec := NewManager(DefaultSplitter, FmtSharp)
+	if err := json.Unmarshal(data, dec); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if k, _ := dec.Key("/cap/cam_########.dng"); k.FileName(86427) != "/cap/cam_01000103.dng" {
+		t.Fatalf("got: %q, want: %q", k.FileName(86427), "/cap/cam_01000103.dng")
+	}
+
+	for _, f := range []string{"/cap/cam_01000024.dng", "/cap/cam_01006000.dng", "/cap/cam_0100.dng"} {
+		if err := man.Add(f); !errors.Is(err, ErrInvalidTimecode) {
+			t.Fatalf("%q: got err: %v, want: %v", f, err, ErrInvalidTimecode)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrTxDone = errors.New("transaction is already committed or discarded")
+
+// A Tx stages adds and removes of files to a manager,
+// and applies them all at once on Commit.
+type Tx struct {
+	m    *Manager
+	ops  []txOp
+	done bool
+}
+
+// txOp is a staged operation of a Tx.
+type txOp struct {
+	fname  string
+	remove bool
+}
+
+// Begin starts a new transaction on the manager.
+// The manager is not changed until the transaction is committed.
+func (m *Manager) Begin() *Tx {
+	return &Tx{m: m}
+}
+
+// Add stages adding a file.
+func (tx *Tx) Add(fname string) {
+	tx.ops = append(tx.ops, txOp{fname: fname})
+}
+
+// Remove stages removing a file.
+func (tx *Tx) Remove(fname string) {
+	tx.ops = append(tx.ops, txOp{fname: fname, remove: true})
+}
+
+// Discard drops all staged operations.
+func (tx *Tx) Discard() {
+	tx.ops = nil
+	tx.done = true
+}
+
+// Commit validates all staged operations together, then applies them.
+//
+// When any of them is invalid, it returns all the errors joined,
+// and the manager is not changed at all.
+// The transaction could not be used after Commit or Discard.
+func (tx *Tx) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+	m := tx.m
+	errs := []error{}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// has holds files' existence changed by the staged operations.
+	type identFrame struct {
+		ident string
+		frame int
+		view  string
+	}
+	has := make(map[identFrame]bool)
+	for _, op := range tx.ops {
+		name, k, frame, view, err := m.split(op.fname)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", op.fname, err))
+			continue
+		}
+
+		nf := identFrame{m.ident(name, k), frame, view}
+		exists, ok := has[nf]
+		if !ok {
+			exists = m.hasFile(name, frame, view)
+		}
+		if op.remove && !exists {
+			errs = append(errs, fmt.Errorf("%s: %w", op.fname, ErrFrameNotFound))
+			continue
+		}
+		if !op.remove && exists {
+			errs = append(errs, fmt.Errorf("%s: %w", op.fname, ErrFrameExists))
+			continue
+		}
+		has[nf] = !op.remove
+	}
+	if len(errs) != 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, op := range tx.ops {
+		if op.remove {
+			m.removeFile(op.fname)
+		} else {
+			m.addFile(op.fname)
+		}
+	}
+	return nil
+}
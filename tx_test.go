@@ -0,0 +1,59 @@
+package sequence
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTx(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	for _, f := range []string{"a.1.exr", "a.2.exr"} {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+
+	tx := man.Begin()
+	tx.Add("a.3.exr")
+	tx.Add("b.1.exr")
+	tx.Remove("a.1.exr")
+	tx.Remove("a.3.exr")
+	tx.Add("a.3.exr")
+	if got, want := man.String(), "a.#.exr 1-2"; got != want {
+		t.Fatalf("manager changed before commit - got: %q, want: %q", got, want)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if got, want := man.String(), "a.#.exr 2-3\nb.#.exr 1"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if err := tx.Commit(); !errors.Is(err, ErrTxDone) {
+		t.Fatalf("got err: %v, want: %v", err, ErrTxDone)
+	}
+
+	tx = man.Begin()
+	tx.Add("c.1.exr")
+	tx.Add("a.2.exr")
+	tx.Remove("b.5.exr")
+	tx.Add("noframe.exr")
+	err := tx.Commit()
+	for _, want := range []error{ErrFrameExists, ErrFrameNotFound, ErrNotSeqfile} {
+		if !errors.Is(err, want) {
+			t.Fatalf("got err: %v, want: %v", err, want)
+		}
+	}
+	if got, want := man.String(), "a.#.exr 2-3\nb.#.exr 1"; got != want {
+		t.Fatalf("manager changed by failed commit - got: %q, want: %q", got, want)
+	}
+
+	tx = man.Begin()
+	tx.Remove("b.1.exr")
+	tx.Discard()
+	if err := tx.Commit(); !errors.Is(err, ErrTxDone) {
+		t.Fatalf("got err: %v, want: %v", err, ErrTxDone)
+	}
+	if got, want := man.String(), "a.#.exr 2-3\nb.#.exr 1"; got != want {
+		t.Fatalf("manager changed by discarded transaction - got: %q, want: %q", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidUDIM = errors.New("invalid udim")
+
+// reUDIMSplit finds right most UDIM tile number (1001-1999)
+// that is not next to other digits.
+var reUDIMSplit = regexp.MustCompile(`^(.*\D)?(1(?:00[1-9]|0[1-9]\d|[1-9]\d\d))(\D*)$`)
+
+// UDIMSplitter is a splitter for UDIM texture tiles, like "tex.1001.png".
+//
+// It only catches 4 digits tile numbers between 1001 and 1999,
+// other files will treated as non-sequence files.
+var UDIMSplitter = NewSplitter(reUDIMSplit)
+
+// FmtUDIM formats a sequence name with "<UDIM>" token,
+// like "tex.<UDIM>.png". Use it with UDIMSplitter.
+var FmtUDIM = NewFormatter(
+	func(pre, digits, post string) string {
+		return pre + "<UDIM>" + post
+	},
+	func(name string) (Key, error) {
+		i := strings.LastIndex(name, "<UDIM>")
+		if i < 0 {
+			return Key{}, fmt.Errorf("%w: %s", ErrNoFrameToken, name)
+		}
+		return Key{Pre: name[:i], Pad: 4, Post: name[i+len("<UDIM>"):]}, nil
+	},
+)
+
+// Tile is a UDIM tile with it's U and V coordinates.
+//
+// U and V start from 0, so tile 1001 is (0, 0), 1010 is (9, 0)
+// and 1011 is (0, 1).
+type Tile struct {
+	UDIM int
+	U    int
+	V    int
+}
+
+// TileOf returns the tile of a UDIM number.
+// It returns ErrInvalidUDIM when the number is not in 1001-1999.
+func TileOf(udim int) (Tile, error) {
+	if udim < 1001 || udim > 1999 {
+		return Tile{}, fmt.Errorf("%w: %d", ErrInvalidUDIM, udim)
+	}
+	n := udim - 1001
+	return Tile{UDIM: udim, U: n % 10, V: n / 10}, nil
+}
+
+// TileAt returns the tile at U and V coordinates.
+// It returns ErrInvalidUDIM when the tile is not in 1001-1999,
+// so the last tile is (8, 99).
+func TileAt(u, v int) (Tile, error) {
+	if u < 0 || u > 9 || v < 0 || v > 99 || 1001+u+v*10 > 1999 {
+		return Tile{}, fmt.Errorf("%w: u%d v%d", ErrInvalidUDIM, u, v)
+	}
+	return Tile{UDIM: 1001 + u + v*10, U: u, V: v}, nil
+}
+
+// isUDIMName reports whether a sequence name is made by FmtUDIM.
+func isUDIMName(name string) bool {
+	return strings.Contains(name, "<UDIM>")
+}
+
+// tileCount returns the number of tiles as a string, like "4 tiles".
+func tileCount(s *Seq) string {
+	if n := s.Len(); n != 1 {
+		return fmt.Sprintf("%d tiles", n)
+	}
+	return "1 tile"
+}
+
+// String returns the tile as "1001 (u0, v0)".
+func (t Tile) String() string {
+	return fmt.Sprintf("%d (u%d, v%d)", t.UDIM, t.U, t.V)
+}
+
+// Tiles returns the frames of the sequence as UDIM tiles, in order.
+// Frames those are not UDIM numbers are skipped.
+func (s *Seq) Tiles() []Tile {
+	tiles := []Tile{}
+	for _, f := range s.sortedFrames() {
+		t, err := TileOf(f)
+		if err != nil {
+			continue
+		}
+		tiles = append(tiles, t)
+	}
+	return tiles
+}
@@ -0,0 +1,73 @@
+package sequence
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUDIM(t *testing.T) {
+	man := NewManager(UDIMSplitter, FmtUDIM)
+	files := []string{
+		"/tex/wood_v2.1001.png",
+		"/tex/wood_v2.1002.png",
+		"/tex/wood_v2.1010.png",
+		"/tex/wood_v2.1011.png",
+		"/tex/wood_v2.1000.png",
+		"/tex/wood_v2.2001.png",
+		"/tex/wood_v2.png",
+		"/tex/tex.11001.png",
+		"/a/v2/tex_21001.png",
+		"/a/v2/tex_1001.png",
+	}
+	for _, f := range files {
+		err := man.Add(f)
+		if err != nil && !errors.Is(err, ErrNotSeqfile) {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+	if got, want := man.SeqNames(), []string{"/a/v2/tex_<UDIM>.png", "/tex/wood_v2.<UDIM>.png"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	s, _ := man.Seq("/tex/wood_v2.<UDIM>.png")
+	want := []Tile{
+		{UDIM: 1001, U: 0, V: 0},
+		{UDIM: 1002, U: 1, V: 0},
+		{UDIM: 1010, U: 9, V: 0},
+		{UDIM: 1011, U: 0, V: 1},
+	}
+	if got := s.Tiles(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %v, want: %v", got, want)
+	}
+	k, _ := man.Key("/tex/wood_v2.<UDIM>.png")
+	if got, want := k.FileName(1011), "/tex/wood_v2.1011.png"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+	if got, want := man.String(), "/a/v2/tex_<UDIM>.png 1 tile\n/tex/wood_v2.<UDIM>.png 4 tiles"; got != want {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}
+
+func TestTile(t *testing.T) {
+	for _, udim := range []int{1001, 1010, 1011, 1999} {
+		tile, err := TileOf(udim)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		got, err := TileAt(tile.U, tile.V)
+		if err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+		if got != tile {
+			t.Fatalf("got: %v, want: %v", got, tile)
+		}
+	}
+	if _, err := TileOf(1000); !errors.Is(err, ErrInvalidUDIM) {
+		t.Fatalf("got err: %v, want: %v", err, ErrInvalidUDIM)
+	}
+	for _, uv := range [][2]int{{10, 0}, {9, 99}, {0, 100}, {-1, 0}} {
+		if _, err := TileAt(uv[0], uv[1]); !errors.Is(err, ErrInvalidUDIM) {
+			t.Fatalf("TileAt(%d, %d) - got err: %v, want: %v", uv[0], uv[1], err, ErrInvalidUDIM)
+		}
+	}
+}
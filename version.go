@@ -0,0 +1,100 @@
+package sequence
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// reVersion finds version tokens like "v001" or "V02",
+// those are not a part of a word.
+var reVersion = regexp.MustCompile(`(^|[^A-Za-z0-9])[vV](\d+)`)
+
+// keyVersion finds version tokens in a key.
+// It returns the key's family, which is the key without it's version digits,
+// and the version of the right most token.
+// It returns false when the key does not have a version token.
+func (m *Manager) keyVersion(k Key) (family string, version int, ok bool) {
+	s := m.norm(k.Pre) + "\x00" + strconv.Itoa(k.Pad) + "\x00" + m.norm(k.Post)
+	locs := reVersion.FindAllStringSubmatchIndex(s, -1)
+	if len(locs) == 0 {
+		return "", 0, false
+	}
+	last := locs[len(locs)-1]
+	version, _ = strconv.Atoi(s[last[4]:last[5]])
+	family = reVersion.ReplaceAllString(s, "${1}v")
+	return family, version, true
+}
+
+// Version returns the version of a sequence, like 3 of "render_v003.####.exr".
+// When the name has multiple version tokens, the right most one is used.
+// It returns false when the sequence does not have a version token.
+func (m *Manager) Version(name string) (int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	k, ok := m.keys[name]
+	if !ok {
+		return 0, false
+	}
+	_, v, ok := m.keyVersion(k)
+	return v, ok
+}
+
+// Versions returns names of the sequences those differ from the sequence
+// only in their versions, including itself, in ascending version order.
+// It returns nil when the sequence is not exist or does not have a version.
+func (m *Manager) Versions(name string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	k, ok := m.keys[name]
+	if !ok {
+		return nil
+	}
+	family, _, ok := m.keyVersion(k)
+	if !ok {
+		return nil
+	}
+	versions := make(map[string]int)
+	names := []string{}
+	for _, n := range m.sortedNames() {
+		f, v, ok := m.keyVersion(m.keys[n])
+		if !ok || f != family {
+			continue
+		}
+		versions[n] = v
+		names = append(names, n)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return versions[names[i]] < versions[names[j]]
+	})
+	return names
+}
+
+// Latest returns sequence names in ascending order, but only the latest
+// version of the sequences those differ only in their versions.
+// Sequences without a version are always returned.
+func (m *Manager) Latest() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	type latest struct {
+		name    string
+		version int
+	}
+	families := make(map[string]latest)
+	names := []string{}
+	for _, n := range m.sortedNames() {
+		f, v, ok := m.keyVersion(m.keys[n])
+		if !ok {
+			names = append(names, n)
+			continue
+		}
+		if l, ok := families[f]; !ok || v > l.version {
+			families[f] = latest{n, v}
+		}
+	}
+	for _, l := range families {
+		names = append(names, l.name)
+	}
+	sort.Slice(names, func(i, j int) bool { return m.lessName(names[i], names[j]) })
+	return names
+}
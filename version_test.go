@@ -0,0 +1,64 @@
+package sequence
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVersions(t *testing.T) {
+	man := NewManager(DefaultSplitter, FmtSharp)
+	files := []string{
+		"/a/render_v003.0101.exr",
+		"/a/render_v010.0101.exr",
+		"/a/render_v002.0101.exr",
+		"/a/v002/comp_v002.0101.exr",
+		"/a/v012/comp_v012.0101.exr",
+		"/a/plate.0101.exr",
+		"/a/dev001.0101.exr",
+	}
+	for _, f := range files {
+		if err := man.Add(f); err != nil {
+			t.Fatalf("got error: %v", err)
+		}
+	}
+
+	if v, ok := man.Version("/a/render_v010.####.exr"); !ok || v != 10 {
+		t.Fatalf("got: %d, %v, want: 10, true", v, ok)
+	}
+	if _, ok := man.Version("/a/dev001.####.exr"); ok {
+		t.Fatalf("dev001 should not be a version")
+	}
+
+	cases := []struct {
+		name string
+		want []string
+	}{
+		{
+			name: "/a/render_v003.####.exr",
+			want: []string{"/a/render_v002.####.exr", "/a/render_v003.####.exr", "/a/render_v010.####.exr"},
+		},
+		{
+			name: "/a/v012/comp_v012.####.exr",
+			want: []string{"/a/v002/comp_v002.####.exr", "/a/v012/comp_v012.####.exr"},
+		},
+		{
+			name: "/a/plate.####.exr",
+			want: nil,
+		},
+	}
+	for _, c := range cases {
+		if got := man.Versions(c.name); !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("%q: got: %q, want: %q", c.name, got, c.want)
+		}
+	}
+
+	want := []string{
+		"/a/dev001.####.exr",
+		"/a/plate.####.exr",
+		"/a/render_v010.####.exr",
+		"/a/v012/comp_v012.####.exr",
+	}
+	if got := man.Latest(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got: %q, want: %q", got, want)
+	}
+}